@@ -0,0 +1,234 @@
+package bytemap
+
+import "fmt"
+
+func add[V int | float64](m, other *[Len]V) {
+	for i := range m {
+		m[i] += other[i]
+	}
+}
+
+func sub[V int | float64](m, other *[Len]V) {
+	for i := range m {
+		m[i] -= other[i]
+	}
+}
+
+func scale[V int | float64](m *[Len]V, k V) {
+	for i := range m {
+		m[i] *= k
+	}
+}
+
+func clamp[V int | float64](m *[Len]V, lo, hi V) {
+	if lo > hi {
+		panic(fmt.Errorf("invalid range: %v - %v", lo, hi))
+	}
+	for i := range m {
+		m[i] = min(max(m[i], lo), hi)
+	}
+}
+
+func elementwiseMax[V int | float64](m, other *[Len]V) {
+	for i := range m {
+		m[i] = max(m[i], other[i])
+	}
+}
+
+func elementwiseMin[V int | float64](m, other *[Len]V) {
+	for i := range m {
+		m[i] = min(m[i], other[i])
+	}
+}
+
+func dot[V int | float64](m, other *[Len]V) V {
+	var sum V
+	for i := range m {
+		sum += m[i] * other[i]
+	}
+	return sum
+}
+
+// Add returns a new Int with the sum of the counts in m and other.
+func (m *Int) Add(other *Int) *Int {
+	m2 := m.Clone()
+	m2.AddInPlace(other)
+	return m2
+}
+
+// AddInPlace adds the counts in other to m.
+func (m *Int) AddInPlace(other *Int) {
+	add((*[Len]int)(m), (*[Len]int)(other))
+}
+
+// Sub returns a new Int with the counts in other subtracted from m.
+// Counts may become negative.
+func (m *Int) Sub(other *Int) *Int {
+	m2 := m.Clone()
+	m2.SubInPlace(other)
+	return m2
+}
+
+// SubInPlace subtracts the counts in other from m.
+// Counts may become negative.
+func (m *Int) SubInPlace(other *Int) {
+	sub((*[Len]int)(m), (*[Len]int)(other))
+}
+
+// Scale returns a new Int with the counts in m multiplied by k.
+func (m *Int) Scale(k int) *Int {
+	m2 := m.Clone()
+	m2.ScaleInPlace(k)
+	return m2
+}
+
+// ScaleInPlace multiplies the counts in m by k.
+func (m *Int) ScaleInPlace(k int) {
+	scale((*[Len]int)(m), k)
+}
+
+// Clamp returns a new Int with the counts in m limited to the range lo to hi.
+// It panics if lo is greater than hi.
+func (m *Int) Clamp(lo, hi int) *Int {
+	m2 := m.Clone()
+	m2.ClampInPlace(lo, hi)
+	return m2
+}
+
+// ClampInPlace limits the counts in m to the range lo to hi.
+// It panics if lo is greater than hi.
+func (m *Int) ClampInPlace(lo, hi int) {
+	clamp((*[Len]int)(m), lo, hi)
+}
+
+// ElementwiseMax returns a new Int with the larger count
+// from m or other for each byte.
+func (m *Int) ElementwiseMax(other *Int) *Int {
+	m2 := m.Clone()
+	m2.ElementwiseMaxInPlace(other)
+	return m2
+}
+
+// ElementwiseMaxInPlace sets each count in m
+// to the larger of it and the count in other.
+func (m *Int) ElementwiseMaxInPlace(other *Int) {
+	elementwiseMax((*[Len]int)(m), (*[Len]int)(other))
+}
+
+// ElementwiseMin returns a new Int with the smaller count
+// from m or other for each byte.
+func (m *Int) ElementwiseMin(other *Int) *Int {
+	m2 := m.Clone()
+	m2.ElementwiseMinInPlace(other)
+	return m2
+}
+
+// ElementwiseMinInPlace sets each count in m
+// to the smaller of it and the count in other.
+func (m *Int) ElementwiseMinInPlace(other *Int) {
+	elementwiseMin((*[Len]int)(m), (*[Len]int)(other))
+}
+
+// Dot returns the sum of the products of the counts in m and other.
+// It does not check for overflow.
+func (m *Int) Dot(other *Int) int {
+	return dot((*[Len]int)(m), (*[Len]int)(other))
+}
+
+// Add returns a new Float with the sum of the values in m and other.
+func (m *Float) Add(other *Float) *Float {
+	m2 := m.Clone()
+	m2.AddInPlace(other)
+	return m2
+}
+
+// AddInPlace adds the values in other to m.
+func (m *Float) AddInPlace(other *Float) {
+	add((*[Len]float64)(m), (*[Len]float64)(other))
+}
+
+// Sub returns a new Float with the values in other subtracted from m.
+func (m *Float) Sub(other *Float) *Float {
+	m2 := m.Clone()
+	m2.SubInPlace(other)
+	return m2
+}
+
+// SubInPlace subtracts the values in other from m.
+func (m *Float) SubInPlace(other *Float) {
+	sub((*[Len]float64)(m), (*[Len]float64)(other))
+}
+
+// Scale returns a new Float with the values in m multiplied by k.
+func (m *Float) Scale(k float64) *Float {
+	m2 := m.Clone()
+	m2.ScaleInPlace(k)
+	return m2
+}
+
+// ScaleInPlace multiplies the values in m by k.
+func (m *Float) ScaleInPlace(k float64) {
+	scale((*[Len]float64)(m), k)
+}
+
+// Clamp returns a new Float with the values in m limited to the range lo to hi.
+// It panics if lo is greater than hi.
+func (m *Float) Clamp(lo, hi float64) *Float {
+	m2 := m.Clone()
+	m2.ClampInPlace(lo, hi)
+	return m2
+}
+
+// ClampInPlace limits the values in m to the range lo to hi.
+// It panics if lo is greater than hi.
+func (m *Float) ClampInPlace(lo, hi float64) {
+	clamp((*[Len]float64)(m), lo, hi)
+}
+
+// ElementwiseMax returns a new Float with the larger value
+// from m or other for each byte.
+func (m *Float) ElementwiseMax(other *Float) *Float {
+	m2 := m.Clone()
+	m2.ElementwiseMaxInPlace(other)
+	return m2
+}
+
+// ElementwiseMaxInPlace sets each value in m
+// to the larger of it and the value in other.
+func (m *Float) ElementwiseMaxInPlace(other *Float) {
+	elementwiseMax((*[Len]float64)(m), (*[Len]float64)(other))
+}
+
+// ElementwiseMin returns a new Float with the smaller value
+// from m or other for each byte.
+func (m *Float) ElementwiseMin(other *Float) *Float {
+	m2 := m.Clone()
+	m2.ElementwiseMinInPlace(other)
+	return m2
+}
+
+// ElementwiseMinInPlace sets each value in m
+// to the smaller of it and the value in other.
+func (m *Float) ElementwiseMinInPlace(other *Float) {
+	elementwiseMin((*[Len]float64)(m), (*[Len]float64)(other))
+}
+
+// Dot returns the sum of the products of the values in m and other.
+func (m *Float) Dot(other *Float) float64 {
+	return dot((*[Len]float64)(m), (*[Len]float64)(other))
+}
+
+// Normalized returns a new Float with each value in m
+// divided by the sum of the values,
+// like SetFrequencies but without changing m.
+// If the values in m sum to 0, it returns a copy of m
+// rather than dividing by zero.
+func (m *Float) Normalized() *Float {
+	m2 := m.Clone()
+	if sum := m.Sum(); sum != 0 {
+		for i := range m2 {
+			m2[i] /= sum
+		}
+	}
+	return m2
+}
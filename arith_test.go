@@ -0,0 +1,88 @@
+package bytemap_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestIntArithmetic(t *testing.T) {
+	var a, b bytemap.Int
+	a.WriteString("aab")
+	b.WriteString("abbbc")
+	orig := a
+	check := func(name string, got *bytemap.Int, want map[byte]int) {
+		t.Helper()
+		var w bytemap.Int
+		for k, v := range want {
+			w[k] = v
+		}
+		if !got.Equals(&w) {
+			t.Errorf("%s = %v; want %v", name, got.ToMap(), want)
+		}
+	}
+	check("Add", a.Add(&b), map[byte]int{'a': 3, 'b': 4, 'c': 1})
+	check("Sub", a.Sub(&b), map[byte]int{'a': 1, 'b': -2, 'c': -1})
+	check("Scale", a.Scale(3), map[byte]int{'a': 6, 'b': 3})
+	check("Clamp", b.Clamp(0, 2), map[byte]int{'a': 1, 'b': 2, 'c': 1})
+	check("ElementwiseMax", a.ElementwiseMax(&b), map[byte]int{'a': 2, 'b': 3, 'c': 1})
+	check("ElementwiseMin", a.ElementwiseMin(&b), map[byte]int{'a': 1, 'b': 1})
+	if got := a.Dot(&b); got != 2*1+1*3 {
+		t.Errorf("Dot = %d", got)
+	}
+	if !a.Equals(&orig) {
+		t.Fatal("allocating methods changed m")
+	}
+	a.AddInPlace(&b)
+	a.SubInPlace(&b)
+	a.ScaleInPlace(2)
+	a.ClampInPlace(0, 3)
+	check("InPlace", &a, map[byte]int{'a': 3, 'b': 2})
+	a.ElementwiseMinInPlace(&b)
+	check("ElementwiseMinInPlace", &a, map[byte]int{'a': 1, 'b': 2})
+	a.ElementwiseMaxInPlace(&b)
+	check("ElementwiseMaxInPlace", &a, map[byte]int{'a': 1, 'b': 3, 'c': 1})
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Clamp(2, 1) should panic")
+		}
+	}()
+	a.Clamp(2, 1)
+}
+
+func TestFloatArithmetic(t *testing.T) {
+	var a, b bytemap.Float
+	a.WriteString("aab")
+	b.WriteString("abbbc")
+	if got := a.Add(&b).Sub(&b); !got.Equals(&a) {
+		t.Fatal(got.ToMap())
+	}
+	if got := a.Scale(0.5).Get('a'); got != 1 {
+		t.Fatal(got)
+	}
+	if got := b.Clamp(0.5, 1.5).ToMap(); got['b'] != 1.5 || got['c'] != 1 || got['z'] != 0.5 {
+		t.Fatal(got)
+	}
+	if got := a.ElementwiseMax(&b).Sum(); got != 6 {
+		t.Fatal(got)
+	}
+	if got := a.ElementwiseMin(&b).Sum(); got != 2 {
+		t.Fatal(got)
+	}
+	if got := a.Dot(&b); got != 5 {
+		t.Fatal(got)
+	}
+	n := a.Normalized()
+	a.SetFrequencies()
+	if !n.Equals(&a) {
+		t.Fatal(n.ToMap(), a.ToMap())
+	}
+	var zero bytemap.Float
+	n = zero.Normalized()
+	for _, v := range n {
+		if v != 0 || math.IsNaN(v) {
+			t.Fatal(n.ToMap())
+		}
+	}
+}
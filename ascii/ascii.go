@@ -0,0 +1,121 @@
+// Package ascii provides predefined byte classes
+// for the POSIX character classes and common RFC alphabets.
+//
+// The classes are immutable.
+// Use Class.Bool or Class.BitField to get a copy which can be modified.
+package ascii
+
+import (
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+// Class is an immutable set of bytes.
+type Class struct {
+	name string
+	m    bytemap.Bool
+	bf   bytemap.BitField
+}
+
+func newClass(name string, ms ...*bytemap.Bool) *Class {
+	m := bytemap.Union(ms...)
+	return &Class{name, *m, *m.ToBitField()}
+}
+
+// Name returns the name of the class, such as "digit".
+func (c *Class) Name() string {
+	return c.name
+}
+
+// String satisfies fmt.Stringer.
+func (c *Class) String() string {
+	return "ascii." + c.name
+}
+
+// Get reports whether b is in the class.
+func (c *Class) Get(b byte) bool {
+	return c.m[b]
+}
+
+// Contains reports whether all bytes in s are in the class.
+func (c *Class) Contains(s string) bool {
+	return c.m.Contains(s)
+}
+
+// ContainsBytes reports whether all bytes in b are in the class.
+func (c *Class) ContainsBytes(b []byte) bool {
+	return c.m.ContainsBytes(b)
+}
+
+// Bool returns a copy of the class as a bytemap.Bool.
+func (c *Class) Bool() *bytemap.Bool {
+	return c.m.Clone()
+}
+
+// BitField returns a copy of the class as a bytemap.BitField.
+func (c *Class) BitField() *bytemap.BitField {
+	return c.bf.Clone()
+}
+
+var (
+	upper = bytemap.Range('A', 'Z')
+	lower = bytemap.Range('a', 'z')
+	digit = bytemap.Range('0', '9')
+	punct = bytemap.Make("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~")
+)
+
+// POSIX character classes, restricted to ASCII.
+var (
+	// ASCII is the bytes 0x00 through 0x7F.
+	ASCII = newClass("ascii", bytemap.Range(0, 0x7F))
+	// Alnum is [A-Za-z0-9].
+	Alnum = newClass("alnum", upper, lower, digit)
+	// Alpha is [A-Za-z].
+	Alpha = newClass("alpha", upper, lower)
+	// Blank is space and tab.
+	Blank = newClass("blank", bytemap.Make(" \t"))
+	// Cntrl is the control characters 0x00 through 0x1F and 0x7F.
+	Cntrl = newClass("cntrl", bytemap.Range(0, 0x1F), bytemap.Make("\x7F"))
+	// Digit is [0-9].
+	Digit = newClass("digit", digit)
+	// Graph is the visible characters 0x21 through 0x7E.
+	Graph = newClass("graph", bytemap.Range('!', '~'))
+	// Lower is [a-z].
+	Lower = newClass("lower", lower)
+	// Print is the visible characters plus space, 0x20 through 0x7E.
+	Print = newClass("print", bytemap.Range(' ', '~'))
+	// Punct is the visible characters which are not alphanumeric.
+	Punct = newClass("punct", punct)
+	// Space is space, \t, \n, \v, \f, and \r.
+	Space = newClass("space", bytemap.Make(" \t\n\v\f\r"))
+	// Upper is [A-Z].
+	Upper = newClass("upper", upper)
+	// Word is [A-Za-z0-9_].
+	Word = newClass("word", upper, lower, digit, bytemap.Make("_"))
+	// XDigit is [0-9A-Fa-f].
+	XDigit = newClass("xdigit", digit, bytemap.Range('A', 'F'), bytemap.Range('a', 'f'))
+)
+
+// Alphabets and character sets defined by RFCs.
+var (
+	// Base64 is the standard base 64 alphabet from RFC 4648, without padding.
+	Base64 = newClass("base64", upper, lower, digit, bytemap.Make("+/"))
+	// Base64URL is the URL and filename safe base 64 alphabet from RFC 4648,
+	// without padding.
+	Base64URL = newClass("base64url", upper, lower, digit, bytemap.Make("-_"))
+	// Base32 is the base 32 alphabet from RFC 4648, without padding.
+	Base32 = newClass("base32", upper, bytemap.Range('2', '7'))
+	// Base32Hex is the extended hex base 32 alphabet from RFC 4648,
+	// without padding.
+	Base32Hex = newClass("base32hex", digit, bytemap.Range('A', 'V'))
+	// Unreserved is the URI unreserved characters from RFC 3986.
+	Unreserved = newClass("unreserved", upper, lower, digit, bytemap.Make("-._~"))
+	// GenDelims is the URI general delimiters from RFC 3986.
+	GenDelims = newClass("gen-delims", bytemap.Make(":/?#[]@"))
+	// SubDelims is the URI subcomponent delimiters from RFC 3986.
+	SubDelims = newClass("sub-delims", bytemap.Make("!$&'()*+,;="))
+	// Reserved is the URI reserved characters from RFC 3986,
+	// the union of GenDelims and SubDelims.
+	Reserved = newClass("reserved", bytemap.Make(":/?#[]@!$&'()*+,;="))
+	// Token is the HTTP token characters from RFC 9110.
+	Token = newClass("token", upper, lower, digit, bytemap.Make("!#$%&'*+-.^_`|~"))
+)
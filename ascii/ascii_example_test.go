@@ -0,0 +1,22 @@
+package ascii_test
+
+import (
+	"fmt"
+
+	"github.com/earthboundkid/bytemap/v2/ascii"
+)
+
+func Example() {
+	fmt.Println(ascii.XDigit.Contains("deadBEEF"))
+	fmt.Println(ascii.Base64URL.Contains("aGVsbG8-d29ybGQ_"))
+	fmt.Println(ascii.Unreserved.Contains("a/b"))
+
+	ident := ascii.Word.Bool()
+	ident.Set('$', true)
+	fmt.Println(ident.Contains("$foo_bar"))
+	// Output:
+	// true
+	// true
+	// false
+	// true
+}
@@ -0,0 +1,108 @@
+package ascii_test
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"unicode"
+
+	"github.com/earthboundkid/bytemap/v2"
+	"github.com/earthboundkid/bytemap/v2/ascii"
+)
+
+func testClass(t *testing.T, c *ascii.Class, want func(r rune) bool) {
+	t.Helper()
+	bf := c.BitField()
+	m := c.Bool()
+	for i := 0; i < bytemap.Len; i++ {
+		b := byte(i)
+		expect := i < 0x80 && want(rune(b))
+		if c.Get(b) != expect || m[b] != expect || bf.Get(b) != expect {
+			t.Errorf("%v: %q want %v", c, b, expect)
+		}
+		if c.Contains(string(b)) != expect ||
+			c.ContainsBytes([]byte{b}) != expect {
+			t.Errorf("%v: %q want %v", c, b, expect)
+		}
+	}
+}
+
+func TestPOSIX(t *testing.T) {
+	for _, tc := range []struct {
+		c    *ascii.Class
+		want func(r rune) bool
+	}{
+		{ascii.ASCII, func(r rune) bool { return r <= unicode.MaxASCII }},
+		{ascii.Alnum, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }},
+		{ascii.Alpha, unicode.IsLetter},
+		{ascii.Blank, func(r rune) bool { return r == ' ' || r == '\t' }},
+		{ascii.Cntrl, unicode.IsControl},
+		{ascii.Digit, unicode.IsDigit},
+		{ascii.Graph, func(r rune) bool { return unicode.IsGraphic(r) && !unicode.IsSpace(r) }},
+		{ascii.Lower, unicode.IsLower},
+		{ascii.Print, unicode.IsPrint},
+		{ascii.Punct, func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) }},
+		{ascii.Space, unicode.IsSpace},
+		{ascii.Upper, unicode.IsUpper},
+		{ascii.Word, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+		}},
+		{ascii.XDigit, func(r rune) bool {
+			return unicode.IsDigit(r) || unicode.In(r, unicode.ASCII_Hex_Digit)
+		}},
+	} {
+		t.Run(tc.c.Name(), func(t *testing.T) {
+			testClass(t, tc.c, tc.want)
+		})
+	}
+}
+
+func alphabet(enc func([]byte) string) func(r rune) bool {
+	var m bytemap.Bool
+	var all [bytemap.Len]byte
+	for i := range all {
+		all[i] = byte(i)
+	}
+	// Encode rotations so every position sees every value
+	for i := range all {
+		m.WriteString(enc(append(all[i:], all[:i]...)))
+	}
+	return func(r rune) bool { return m[r] }
+}
+
+func TestRFC(t *testing.T) {
+	for _, tc := range []struct {
+		c    *ascii.Class
+		want func(r rune) bool
+	}{
+		{ascii.Base64, alphabet(base64.RawStdEncoding.EncodeToString)},
+		{ascii.Base64URL, alphabet(base64.RawURLEncoding.EncodeToString)},
+		{ascii.Base32, alphabet(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString)},
+		{ascii.Base32Hex, alphabet(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString)},
+		{ascii.Unreserved, func(r rune) bool {
+			return url.PathEscape(string(r)) == string(r) &&
+				url.QueryEscape(string(r)) == string(r)
+		}},
+		{ascii.Reserved, func(r rune) bool {
+			return ascii.GenDelims.Get(byte(r)) || ascii.SubDelims.Get(byte(r))
+		}},
+		{ascii.Token, func(r rune) bool {
+			return ascii.Graph.Get(byte(r)) && !bytemap.Make(`"(),/:;<=>?@[\]{}`).Get(byte(r))
+		}},
+	} {
+		t.Run(tc.c.Name(), func(t *testing.T) {
+			testClass(t, tc.c, tc.want)
+		})
+	}
+}
+
+func TestImmutable(t *testing.T) {
+	m := ascii.Digit.Bool()
+	m.Set('x', true)
+	bf := ascii.Digit.BitField()
+	bf.Set('x', true)
+	if ascii.Digit.Get('x') || ascii.Digit.Bool().Get('x') || ascii.Digit.BitField().Get('x') {
+		t.Fatal("class was modified")
+	}
+}
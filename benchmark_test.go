@@ -2,9 +2,11 @@ package bytemap_test
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"regexp"
+	"sync"
 	"testing"
 
 	"github.com/earthboundkid/bytemap/v2"
@@ -205,3 +207,160 @@ func BenchmarkBitFieldContains(b *testing.B) {
 	}
 	globalMatch = match
 }
+
+var globalBitSet256 *bytemap.BitSet256
+
+func BenchmarkBitSet256Copy(b *testing.B) {
+	data, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var m bytemap.BitSet256
+	b.ResetTimer()
+	for range b.N {
+		_, err = io.Copy(&m, bytes.NewReader(data))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	globalBitSet256 = &m
+}
+
+func BenchmarkBitSet256WriteString(b *testing.B) {
+	data, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := string(data)
+	var m bytemap.BitSet256
+	b.ResetTimer()
+	for range b.N {
+		m.WriteString(s)
+	}
+	globalBitSet256 = &m
+}
+
+func BenchmarkBitSet256Contains(b *testing.B) {
+	m := bytemap.Make("0123456789").ToBitSet256()
+	b.ResetTimer()
+	var match bool
+	for i := range b.N {
+		s := testStrings[i%len(testStrings)]
+		match = m.Contains(s)
+	}
+	globalMatch = match
+}
+
+func benchmarkContainsMobyDick[M ByteMapContainer](b *testing.B, toMap func(*bytemap.Bool) M) {
+	data, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	m := toMap(bytemap.Make(data))
+	s := string(data)
+	b.SetBytes(int64(len(s)))
+	b.ResetTimer()
+	var match bool
+	for range b.N {
+		match = m.Contains(s)
+	}
+	if !match {
+		b.Fatal("should match")
+	}
+	globalMatch = match
+}
+
+func BenchmarkBoolContainsMobyDick(b *testing.B) {
+	benchmarkContainsMobyDick(b, func(m *bytemap.Bool) *bytemap.Bool {
+		return m
+	})
+}
+
+func BenchmarkBitFieldContainsMobyDick(b *testing.B) {
+	benchmarkContainsMobyDick(b, (*bytemap.Bool).ToBitField)
+}
+
+func BenchmarkBitSet256ContainsMobyDick(b *testing.B) {
+	benchmarkContainsMobyDick(b, (*bytemap.Bool).ToBitSet256)
+}
+
+type mutexInt struct {
+	mu sync.Mutex
+	bytemap.Int
+}
+
+func (m *mutexInt) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Int.Write(p)
+}
+
+func benchmarkConcurrentWrite(b *testing.B, w io.Writer, size int) {
+	data, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	data = data[:size]
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			w.Write(data)
+		}
+	})
+}
+
+func BenchmarkConcurrentWrite(b *testing.B) {
+	for _, size := range []int{16, 4096} {
+		b.Run(fmt.Sprintf("Mutex/%d", size), func(b *testing.B) {
+			benchmarkConcurrentWrite(b, new(mutexInt), size)
+		})
+		b.Run(fmt.Sprintf("AtomicInt/%d", size), func(b *testing.B) {
+			benchmarkConcurrentWrite(b, new(bytemap.AtomicInt), size)
+		})
+		b.Run(fmt.Sprintf("ShardedInt/%d", size), func(b *testing.B) {
+			benchmarkConcurrentWrite(b, bytemap.NewShardedInt(), size)
+		})
+	}
+}
+
+func BenchmarkCountFile(b *testing.B) {
+	info, err := os.Stat("testdata/moby-dick.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, workers := range []int{1, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(info.Size())
+			for range b.N {
+				if _, err := bytemap.CountFile("testdata/moby-dick.txt", workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkIntWrite(b *testing.B) {
+	data, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, size := range []int{64, 4096, len(data)} {
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			var m bytemap.Int
+			for range b.N {
+				m.Write(data[:size])
+			}
+		})
+	}
+	b.Run("zeros", func(b *testing.B) {
+		zeros := make([]byte, len(data))
+		b.SetBytes(int64(len(zeros)))
+		var m bytemap.Int
+		for range b.N {
+			m.Write(zeros)
+		}
+	})
+}
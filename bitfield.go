@@ -33,26 +33,18 @@ func (m *BitField) WriteString(s string) (n int, err error) {
 
 // Contains reports whether all bytes in s are already in m.
 func (m *BitField) Contains(s string) bool {
-	for _, c := range []byte(s) {
-		i := c / 8
-		mask := byte(1 << (c % 8))
-		if m[i]&mask == 0 {
-			return false
-		}
+	if len(s) < 16 {
+		return indexFunc(s, func(c byte) bool { return m[c/8]&(1<<(c%8)) == 0 }) == -1
 	}
-	return true
+	return bitFieldIndex(m, bytesOf(s), false) == -1
 }
 
 // ContainsBytes reports whether all bytes in b are already in m.
 func (m *BitField) ContainsBytes(b []byte) bool {
-	for _, c := range b {
-		mask := byte(1 << (c % 8))
-		masked := m[c/8] & mask
-		if contains := masked != 0; !contains {
-			return false
-		}
+	if len(b) < 16 {
+		return indexFunc(b, func(c byte) bool { return m[c/8]&(1<<(c%8)) == 0 }) == -1
 	}
-	return true
+	return bitFieldIndex(m, b, false) == -1
 }
 
 // ContainsReader reports whether all bytes in r are already in m.
@@ -0,0 +1,43 @@
+//go:build go1.23 || goexperiment.rangefunc
+
+package bytemap
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// All returns a sequence of every byte and its value in m, in byte order.
+func (m *BitField) All() iter.Seq2[byte, bool] {
+	return func(yield func(byte, bool) bool) {
+		for c := 0; c < Len; c++ {
+			if !yield(byte(c), m.Get(byte(c))) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the members of m, in byte order.
+func (m *BitField) Keys() iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		for i := range bitFieldWords {
+			for w := m.word(i); w != 0; w &= w - 1 {
+				if !yield(byte(i*64 + bits.TrailingZeros64(w))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the value for every byte in m, in byte order.
+func (m *BitField) Values() iter.Seq[bool] {
+	return func(yield func(bool) bool) {
+		for c := 0; c < Len; c++ {
+			if !yield(m.Get(byte(c))) {
+				return
+			}
+		}
+	}
+}
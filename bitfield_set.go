@@ -0,0 +1,121 @@
+package bytemap
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const bitFieldWords = BitFieldLen / 8
+
+// word returns the ith 64-bit word of m.
+func (m *BitField) word(i int) uint64 {
+	return binary.LittleEndian.Uint64(m[i*8:])
+}
+
+// setWord sets the ith 64-bit word of m.
+func (m *BitField) setWord(i int, w uint64) {
+	binary.LittleEndian.PutUint64(m[i*8:], w)
+}
+
+// combine sets each word of m to op of it and the same word of other.
+func (m *BitField) combine(other *BitField, op func(a, b uint64) uint64) *BitField {
+	for i := range bitFieldWords {
+		m.setWord(i, op(m.word(i), other.word(i)))
+	}
+	return m
+}
+
+func or(a, b uint64) uint64     { return a | b }
+func and(a, b uint64) uint64    { return a & b }
+func andNot(a, b uint64) uint64 { return a &^ b }
+func xor(a, b uint64) uint64    { return a ^ b }
+
+// Union returns a new BitField containing the members of m or other.
+func (m *BitField) Union(other *BitField) *BitField {
+	return m.Clone().combine(other, or)
+}
+
+// UnionInPlace adds the members of other to m.
+func (m *BitField) UnionInPlace(other *BitField) {
+	m.combine(other, or)
+}
+
+// Intersect returns a new BitField containing the members of both m and other.
+func (m *BitField) Intersect(other *BitField) *BitField {
+	return m.Clone().combine(other, and)
+}
+
+// IntersectInPlace removes the members of m which are not in other.
+func (m *BitField) IntersectInPlace(other *BitField) {
+	m.combine(other, and)
+}
+
+// Difference returns a new BitField containing the members of m which are not in other.
+func (m *BitField) Difference(other *BitField) *BitField {
+	return m.Clone().combine(other, andNot)
+}
+
+// DifferenceInPlace removes the members of other from m.
+func (m *BitField) DifferenceInPlace(other *BitField) {
+	m.combine(other, andNot)
+}
+
+// SymmetricDifference returns a new BitField containing the members of
+// either m or other but not both.
+func (m *BitField) SymmetricDifference(other *BitField) *BitField {
+	return m.Clone().combine(other, xor)
+}
+
+// SymmetricDifferenceInPlace sets m to the members of
+// either m or other but not both.
+func (m *BitField) SymmetricDifferenceInPlace(other *BitField) {
+	m.combine(other, xor)
+}
+
+// Complement returns a new BitField containing the bytes which are not in m.
+func (m *BitField) Complement() *BitField {
+	m2 := m.Clone()
+	m2.ComplementInPlace()
+	return m2
+}
+
+// ComplementInPlace sets m to the bytes which are not in m.
+func (m *BitField) ComplementInPlace() {
+	for i := range bitFieldWords {
+		m.setWord(i, ^m.word(i))
+	}
+}
+
+// IsSubsetOf reports whether every member of m is in other.
+func (m *BitField) IsSubsetOf(other *BitField) bool {
+	for i := range bitFieldWords {
+		if m.word(i)&^other.word(i) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf reports whether every member of other is in m.
+func (m *BitField) IsSupersetOf(other *BitField) bool {
+	return other.IsSubsetOf(m)
+}
+
+// Disjoint reports whether m and other have no members in common.
+func (m *BitField) Disjoint(other *BitField) bool {
+	for i := range bitFieldWords {
+		if m.word(i)&other.word(i) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of members of m.
+func (m *BitField) Len() int {
+	n := 0
+	for i := range bitFieldWords {
+		n += bits.OnesCount64(m.word(i))
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package bytemap_test
+
+import (
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func FuzzBitFieldSetAlgebra(f *testing.F) {
+	f.Add("", "")
+	f.Add("a", "a")
+	f.Add("abc", "bcd")
+	f.Add("abc", "xyz")
+	f.Add("\x00\x3f\x40\x7f\x80\xbf\xc0\xff", "\x3f\x40\xc0")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		ma, mb := bytemap.Make(a), bytemap.Make(b)
+		bfa, bfb := ma.ToBitField(), mb.ToBitField()
+		orig := bfa.Clone()
+		check := func(name string, got *bytemap.BitField, want *bytemap.Bool) {
+			t.Helper()
+			if !got.ToBool().Equals(want) {
+				t.Fatalf("%s(%q, %q) = %v; want %v",
+					name, a, b, got.ToBool(), want)
+			}
+		}
+		inPlace := func(op func(m *bytemap.BitField)) *bytemap.BitField {
+			m := bfa.Clone()
+			op(m)
+			return m
+		}
+		union := bytemap.Union(ma, mb)
+		intersection := bytemap.Intersection(ma, mb)
+		difference := bytemap.Difference(ma, mb)
+		symmetric := bytemap.Difference(union, intersection)
+
+		check("Union", bfa.Union(bfb), union)
+		check("UnionInPlace", inPlace(func(m *bytemap.BitField) {
+			m.UnionInPlace(bfb)
+		}), union)
+		check("Intersect", bfa.Intersect(bfb), intersection)
+		check("IntersectInPlace", inPlace(func(m *bytemap.BitField) {
+			m.IntersectInPlace(bfb)
+		}), intersection)
+		check("Difference", bfa.Difference(bfb), difference)
+		check("DifferenceInPlace", inPlace(func(m *bytemap.BitField) {
+			m.DifferenceInPlace(bfb)
+		}), difference)
+		check("SymmetricDifference", bfa.SymmetricDifference(bfb), symmetric)
+		check("SymmetricDifferenceInPlace", inPlace(func(m *bytemap.BitField) {
+			m.SymmetricDifferenceInPlace(bfb)
+		}), symmetric)
+		check("Complement", bfa.Complement(), ma.Invert())
+		check("ComplementInPlace", inPlace(func(m *bytemap.BitField) {
+			m.ComplementInPlace()
+		}), ma.Invert())
+
+		if !bfa.Equals(orig) {
+			t.Fatal("allocating operation modified receiver")
+		}
+
+		subset := difference.Equals(&bytemap.Bool{})
+		if bfa.IsSubsetOf(bfb) != subset || bfb.IsSupersetOf(bfa) != subset {
+			t.Fatalf("IsSubsetOf(%q, %q) != %v", a, b, subset)
+		}
+		disjoint := intersection.Equals(&bytemap.Bool{})
+		if bfa.Disjoint(bfb) != disjoint || bfb.Disjoint(bfa) != disjoint {
+			t.Fatalf("Disjoint(%q, %q) != %v", a, b, disjoint)
+		}
+		n := 0
+		for _, v := range ma {
+			if v {
+				n++
+			}
+		}
+		if bfa.Len() != n {
+			t.Fatalf("Len(%q) = %d; want %d", a, bfa.Len(), n)
+		}
+	})
+}
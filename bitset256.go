@@ -0,0 +1,296 @@
+package bytemap
+
+import (
+	"io"
+	"math/bits"
+)
+
+// BitSet256 is a map from byte to bool backed by four 64-bit words.
+// It is the same size as a BitField,
+// but because it works a word at a time instead of a byte at a time,
+// it is generally faster.
+type BitSet256 [4]uint64
+
+var _ io.Writer = (*BitSet256)(nil)
+
+// Write satisfies io.Writer.
+func (m *BitSet256) Write(p []byte) (int, error) {
+	for _, c := range p {
+		m[c>>6] |= 1 << (c & 63)
+	}
+	return len(p), nil
+}
+
+var _ io.StringWriter = (*BitSet256)(nil)
+
+// WriteString satisfies io.StringWriter.
+func (m *BitSet256) WriteString(s string) (n int, err error) {
+	for _, c := range []byte(s) {
+		m[c>>6] |= 1 << (c & 63)
+	}
+	return len(s), nil
+}
+
+// Contains reports whether all bytes in s are already in m.
+func (m *BitSet256) Contains(s string) bool {
+	if len(s) < 16 {
+		return indexFunc(s, func(c byte) bool { return m[c>>6]&(1<<(c&63)) == 0 }) == -1
+	}
+	return bitSet256Index(m, bytesOf(s), false) == -1
+}
+
+// ContainsBytes reports whether all bytes in b are already in m.
+func (m *BitSet256) ContainsBytes(b []byte) bool {
+	if len(b) < 16 {
+		return indexFunc(b, func(c byte) bool { return m[c>>6]&(1<<(c&63)) == 0 }) == -1
+	}
+	return bitSet256Index(m, b, false) == -1
+}
+
+// ContainsReader reports whether all bytes in r are already in m.
+// If the reader fails, it returns false, error.
+// If it reads to io.EOF, it returns true, nil.
+func (m *BitSet256) ContainsReader(r io.Reader) (bool, error) {
+	var buf [4096]byte
+	for {
+		n, err := r.Read(buf[:])
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		if !m.ContainsBytes(buf[:n]) {
+			return false, nil
+		}
+		if err == io.EOF {
+			return true, nil
+		}
+	}
+}
+
+// ToMap makes a map[byte]bool from the bytemap.
+func (m *BitSet256) ToMap() map[byte]bool {
+	m2 := make(map[byte]bool)
+	for c := 0; c < Len; c++ {
+		m2[byte(c)] = m.Get(byte(c))
+	}
+	return m2
+}
+
+// Equals reports if two BitSet256s are equal.
+func (m *BitSet256) Equals(other *BitSet256) bool {
+	return *m == *other
+}
+
+// Set sets one byte in the BitSet256 byte map.
+func (m *BitSet256) Set(key byte, value bool) {
+	if value {
+		m[key>>6] |= 1 << (key & 63)
+	} else {
+		m[key>>6] &^= 1 << (key & 63)
+	}
+}
+
+// Get looks up one byte in the BitSet256 byte map.
+func (m *BitSet256) Get(key byte) bool {
+	return m[key>>6]&(1<<(key&63)) != 0
+}
+
+// Clone copies m.
+func (m *BitSet256) Clone() *BitSet256 {
+	m2 := *m
+	return &m2
+}
+
+// ToBool returns a Bool equivalent to m.
+func (m *BitSet256) ToBool() *Bool {
+	var m2 Bool
+	for c := 0; c < Len; c++ {
+		m2[c] = m.Get(byte(c))
+	}
+	return &m2
+}
+
+// ToBitField returns a BitField equivalent to m.
+func (m *BitSet256) ToBitField() *BitField {
+	var bf BitField
+	for i, w := range m {
+		bf.setWord(i, w)
+	}
+	return &bf
+}
+
+// ToBitSet256 returns a BitSet256 equivalent to m.
+func (m *Bool) ToBitSet256() *BitSet256 {
+	var m2 BitSet256
+	for c, v := range m {
+		if v {
+			m2[c>>6] |= 1 << (c & 63)
+		}
+	}
+	return &m2
+}
+
+// ToBitSet256 returns a BitSet256 equivalent to m.
+func (m *BitField) ToBitSet256() *BitSet256 {
+	var m2 BitSet256
+	for i := range m2 {
+		m2[i] = m.word(i)
+	}
+	return &m2
+}
+
+// Len returns the number of members of m.
+func (m *BitSet256) Len() int {
+	return bits.OnesCount64(m[0]) + bits.OnesCount64(m[1]) +
+		bits.OnesCount64(m[2]) + bits.OnesCount64(m[3])
+}
+
+// Min returns the smallest member of m.
+// If m is empty, it returns 0, false.
+func (m *BitSet256) Min() (byte, bool) {
+	for i, w := range m {
+		if w != 0 {
+			return byte(i*64 + bits.TrailingZeros64(w)), true
+		}
+	}
+	return 0, false
+}
+
+// Max returns the largest member of m.
+// If m is empty, it returns 0, false.
+func (m *BitSet256) Max() (byte, bool) {
+	for i := len(m) - 1; i >= 0; i-- {
+		if w := m[i]; w != 0 {
+			return byte(i*64 + 63 - bits.LeadingZeros64(w)), true
+		}
+	}
+	return 0, false
+}
+
+// Next returns the smallest member of m greater than from.
+// If there is none, it returns 0, false.
+func (m *BitSet256) Next(from byte) (byte, bool) {
+	if from == Len-1 {
+		return 0, false
+	}
+	c := int(from) + 1
+	// mask off the bits below c in its word
+	w := m[c>>6] &^ (1<<(c&63) - 1)
+	for i := c >> 6; ; {
+		if w != 0 {
+			return byte(i*64 + bits.TrailingZeros64(w)), true
+		}
+		i++
+		if i == len(m) {
+			return 0, false
+		}
+		w = m[i]
+	}
+}
+
+// Prev returns the largest member of m less than from.
+// If there is none, it returns 0, false.
+func (m *BitSet256) Prev(from byte) (byte, bool) {
+	if from == 0 {
+		return 0, false
+	}
+	c := int(from) - 1
+	// mask off the bits above c in its word
+	w := m[c>>6] & (2<<(c&63) - 1)
+	for i := c >> 6; ; {
+		if w != 0 {
+			return byte(i*64 + 63 - bits.LeadingZeros64(w)), true
+		}
+		i--
+		if i < 0 {
+			return 0, false
+		}
+		w = m[i]
+	}
+}
+
+// Union returns a new BitSet256 containing the members of m or other.
+func (m *BitSet256) Union(other *BitSet256) *BitSet256 {
+	m2 := *m
+	m2.UnionInPlace(other)
+	return &m2
+}
+
+// UnionInPlace adds the members of other to m.
+func (m *BitSet256) UnionInPlace(other *BitSet256) {
+	for i := range m {
+		m[i] |= other[i]
+	}
+}
+
+// Intersect returns a new BitSet256 containing the members of both m and other.
+func (m *BitSet256) Intersect(other *BitSet256) *BitSet256 {
+	m2 := *m
+	m2.IntersectInPlace(other)
+	return &m2
+}
+
+// IntersectInPlace removes the members of m which are not in other.
+func (m *BitSet256) IntersectInPlace(other *BitSet256) {
+	for i := range m {
+		m[i] &= other[i]
+	}
+}
+
+// Difference returns a new BitSet256 containing the members of m which are not in other.
+func (m *BitSet256) Difference(other *BitSet256) *BitSet256 {
+	m2 := *m
+	m2.DifferenceInPlace(other)
+	return &m2
+}
+
+// DifferenceInPlace removes the members of other from m.
+func (m *BitSet256) DifferenceInPlace(other *BitSet256) {
+	for i := range m {
+		m[i] &^= other[i]
+	}
+}
+
+// SymmetricDifference returns a new BitSet256 containing the members of
+// either m or other but not both.
+func (m *BitSet256) SymmetricDifference(other *BitSet256) *BitSet256 {
+	m2 := *m
+	m2.SymmetricDifferenceInPlace(other)
+	return &m2
+}
+
+// SymmetricDifferenceInPlace sets m to the members of
+// either m or other but not both.
+func (m *BitSet256) SymmetricDifferenceInPlace(other *BitSet256) {
+	for i := range m {
+		m[i] ^= other[i]
+	}
+}
+
+// Complement returns a new BitSet256 containing the bytes which are not in m.
+func (m *BitSet256) Complement() *BitSet256 {
+	m2 := *m
+	m2.ComplementInPlace()
+	return &m2
+}
+
+// ComplementInPlace sets m to the bytes which are not in m.
+func (m *BitSet256) ComplementInPlace() {
+	for i := range m {
+		m[i] = ^m[i]
+	}
+}
+
+// IsSubsetOf reports whether every member of m is in other.
+func (m *BitSet256) IsSubsetOf(other *BitSet256) bool {
+	return m[0]&^other[0]|m[1]&^other[1]|m[2]&^other[2]|m[3]&^other[3] == 0
+}
+
+// IsSupersetOf reports whether every member of other is in m.
+func (m *BitSet256) IsSupersetOf(other *BitSet256) bool {
+	return other.IsSubsetOf(m)
+}
+
+// Disjoint reports whether m and other have no members in common.
+func (m *BitSet256) Disjoint(other *BitSet256) bool {
+	return m[0]&other[0]|m[1]&other[1]|m[2]&other[2]|m[3]&other[3] == 0
+}
@@ -0,0 +1,128 @@
+package bytemap
+
+// IndexAny returns the index of the first byte of s which is in m,
+// or -1 if there is none.
+func (m *BitSet256) IndexAny(s string) int {
+	return bitSet256Index(m, bytesOf(s), true)
+}
+
+// IndexAnyBytes returns the index of the first byte of b which is in m,
+// or -1 if there is none.
+func (m *BitSet256) IndexAnyBytes(b []byte) int {
+	return bitSet256Index(m, b, true)
+}
+
+// LastIndexAny returns the index of the last byte of s which is in m,
+// or -1 if there is none.
+func (m *BitSet256) LastIndexAny(s string) int {
+	return lastIndexFunc(s, func(c byte) bool { return m[c>>6]&(1<<(c&63)) != 0 })
+}
+
+// LastIndexAnyBytes returns the index of the last byte of b which is in m,
+// or -1 if there is none.
+func (m *BitSet256) LastIndexAnyBytes(b []byte) int {
+	return lastIndexFunc(b, func(c byte) bool { return m[c>>6]&(1<<(c&63)) != 0 })
+}
+
+// IndexNotIn returns the index of the first byte of s which is not in m,
+// or -1 if m contains all of s.
+func (m *BitSet256) IndexNotIn(s string) int {
+	return bitSet256Index(m, bytesOf(s), false)
+}
+
+// IndexNotInBytes returns the index of the first byte of b which is not in m,
+// or -1 if m contains all of b.
+func (m *BitSet256) IndexNotInBytes(b []byte) int {
+	return bitSet256Index(m, b, false)
+}
+
+// LastIndexNotIn returns the index of the last byte of s which is not in m,
+// or -1 if m contains all of s.
+func (m *BitSet256) LastIndexNotIn(s string) int {
+	return lastIndexFunc(s, func(c byte) bool { return m[c>>6]&(1<<(c&63)) == 0 })
+}
+
+// LastIndexNotInBytes returns the index of the last byte of b which is not in m,
+// or -1 if m contains all of b.
+func (m *BitSet256) LastIndexNotInBytes(b []byte) int {
+	return lastIndexFunc(b, func(c byte) bool { return m[c>>6]&(1<<(c&63)) == 0 })
+}
+
+// Count returns the number of bytes of s which are in m.
+func (m *BitSet256) Count(s string) int {
+	return countFunc(s, func(c byte) bool { return m[c>>6]&(1<<(c&63)) != 0 })
+}
+
+// CountBytes returns the number of bytes of b which are in m.
+func (m *BitSet256) CountBytes(b []byte) int {
+	return countFunc(b, func(c byte) bool { return m[c>>6]&(1<<(c&63)) != 0 })
+}
+
+// TrimLeft returns s without any leading bytes which are in m.
+func (m *BitSet256) TrimLeft(s string) string {
+	return trimLeft(s, m.IndexNotIn)
+}
+
+// TrimLeftBytes returns a subslice of b without any leading bytes which are in m.
+func (m *BitSet256) TrimLeftBytes(b []byte) []byte {
+	return trimLeft(b, m.IndexNotInBytes)
+}
+
+// TrimRight returns s without any trailing bytes which are in m.
+func (m *BitSet256) TrimRight(s string) string {
+	return trimRight(s, m.LastIndexNotIn)
+}
+
+// TrimRightBytes returns a subslice of b without any trailing bytes which are in m.
+func (m *BitSet256) TrimRightBytes(b []byte) []byte {
+	return trimRight(b, m.LastIndexNotInBytes)
+}
+
+// Trim returns s without any leading or trailing bytes which are in m.
+func (m *BitSet256) Trim(s string) string {
+	return m.TrimRight(m.TrimLeft(s))
+}
+
+// TrimBytes returns a subslice of b without any leading or trailing bytes which are in m.
+func (m *BitSet256) TrimBytes(b []byte) []byte {
+	return m.TrimRightBytes(m.TrimLeftBytes(b))
+}
+
+// Split slices s into the substrings separated by each byte in m.
+// If s contains no bytes in m, Split returns a slice containing only s.
+func (m *BitSet256) Split(s string) []string {
+	return split(s, m.IndexAny)
+}
+
+// SplitBytes slices b into the subslices separated by each byte in m.
+// If b contains no bytes in m, SplitBytes returns a slice containing only b.
+func (m *BitSet256) SplitBytes(b []byte) [][]byte {
+	return clip(split(b, m.IndexAnyBytes))
+}
+
+// Fields splits s around each run of bytes in m.
+// It never returns empty strings.
+func (m *BitSet256) Fields(s string) []string {
+	return fields(s, m.IndexAny, m.IndexNotIn)
+}
+
+// FieldsBytes splits b around each run of bytes in m.
+// It never returns empty slices.
+func (m *BitSet256) FieldsBytes(b []byte) [][]byte {
+	return clip(fields(b, m.IndexAnyBytes, m.IndexNotInBytes))
+}
+
+// Cut slices s around the first byte in m,
+// returning the text before and after it.
+// If s contains no bytes in m, Cut returns s, "", false.
+func (m *BitSet256) Cut(s string) (before, after string, found bool) {
+	return cut(s, m.IndexAny)
+}
+
+// CutBytes slices b around the first byte in m,
+// returning the subslices before and after it.
+// If b contains no bytes in m, CutBytes returns b, an empty slice, false.
+func (m *BitSet256) CutBytes(b []byte) (before, after []byte, found bool) {
+	before, after, found = cut(b, m.IndexAnyBytes)
+	return before[:len(before):len(before)], after, found
+}
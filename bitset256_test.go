@@ -0,0 +1,231 @@
+package bytemap_test
+
+import (
+	"io"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func FuzzMakeBitSet256(f *testing.F) {
+	f.Add("", "")
+	f.Add("a", "a")
+	f.Add("a", "b")
+	f.Add("ab", "ab")
+	f.Add("ab", "abc")
+	for i := 0; i < 1_000_000; i = (i + 1) * 2 {
+		for j := 0; j < 3; j++ {
+			s := strings.Repeat("a", i)
+			charset := strings.Repeat("a", j)
+			f.Add(s, charset)
+			f.Add(s+"b", charset)
+		}
+	}
+	f.Fuzz(func(t *testing.T, s, charset string) {
+		want := naiveContains(s, charset)
+		t.Run("Make", func(t *testing.T) {
+			m := bytemap.Make(charset).ToBitSet256()
+			testContainment(t, m, s, charset, want)
+		})
+		t.Run("WriteString", func(t *testing.T) {
+			m := &bytemap.BitSet256{}
+			n, err := m.WriteString(charset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(charset) {
+				t.Fatal(len(charset))
+			}
+			testContainment(t, m, s, charset, want)
+		})
+		t.Run("Write", func(t *testing.T) {
+			m := &bytemap.BitSet256{}
+			n, err := m.Write([]byte(charset))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(charset) {
+				t.Fatal(len(charset))
+			}
+			testContainment(t, m, s, charset, want)
+		})
+		// Test io.Copy
+		t.Run("Copy", func(t *testing.T) {
+			m := &bytemap.BitSet256{}
+			n64, err := io.Copy(m, strings.NewReader(charset))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n64 != int64(len(charset)) {
+				t.Fatal(len(charset))
+			}
+			testContainment(t, m, s, charset, want)
+		})
+	})
+}
+
+func FuzzBitSet256ToMap(f *testing.F) {
+	f.Add("", "")
+	f.Add("a", "b")
+	f.Add(
+		"the quick brown fox jumps over a lazy dog.",
+		"abcdefghijklmnopqrstuvwxyz. ",
+	)
+	f.Fuzz(func(t *testing.T, a, b string) {
+		aNaive := naiveMap(a)
+		aMap := bytemap.Make(a).ToBitSet256()
+		if !maps.Equal(aNaive, aMap.ToMap()) {
+			t.Fatalf("input=%q want=%v got=%v",
+				a, aNaive, aMap.ToMap())
+		}
+		testGet(t, aMap, aNaive)
+		bNaive := naiveMap(b)
+		bMap := bytemap.Make(b).ToBitSet256()
+		if maps.Equal(aNaive, bNaive) != aMap.Equals(bMap) {
+			t.Fatal(aMap, bMap)
+		}
+		if !aMap.ToBool().Equals(bytemap.Make(a)) ||
+			!aMap.ToBitField().Equals(bytemap.Make(a).ToBitField()) ||
+			!aMap.ToBitField().ToBitSet256().Equals(aMap) {
+			t.Fatal(a, aMap)
+		}
+	})
+}
+
+func FuzzBitSet256Set(f *testing.F) {
+	f.Add("", "", "")
+	f.Add("a", "a", "a")
+	f.Add("abc", "bcde", "b")
+	f.Fuzz(func(t *testing.T, add, remove, restore string) {
+		var bs bytemap.BitSet256
+		m := make(map[byte]bool)
+		for _, c := range []byte(add) {
+			bs.Set(c, true)
+			m[c] = true
+		}
+		for _, c := range []byte(remove) {
+			bs.Set(c, false)
+			m[c] = false
+		}
+		for _, c := range []byte(restore) {
+			bs.Set(c, true)
+			m[c] = true
+		}
+		// Fill in blanks
+		for i := 0; i < bytemap.Len; i++ {
+			m[byte(i)] = m[byte(i)]
+		}
+		if !maps.Equal(bs.ToMap(), m) {
+			t.Fatal(bs)
+		}
+		if !bs.Clone().Equals(&bs) {
+			t.Fatal(bs)
+		}
+	})
+}
+
+func FuzzBitSet256Order(f *testing.F) {
+	f.Add("")
+	f.Add("a")
+	f.Add("\x00")
+	f.Add("\xff")
+	f.Add("\x00\x3f\x40\x7f\x80\xbf\xc0\xff")
+	f.Fuzz(func(t *testing.T, charset string) {
+		m := bytemap.Make(charset)
+		bs := m.ToBitSet256()
+		var members []byte
+		for c := range bytemap.Len {
+			if m[c] {
+				members = append(members, byte(c))
+			}
+		}
+		if bs.Len() != len(members) {
+			t.Fatal(bs.Len(), len(members))
+		}
+		c, ok := bs.Min()
+		if ok != (len(members) > 0) || ok && c != members[0] {
+			t.Fatal("Min", c, ok)
+		}
+		c, ok = bs.Max()
+		if ok != (len(members) > 0) || ok && c != members[len(members)-1] {
+			t.Fatal("Max", c, ok)
+		}
+		var forward, backward []byte
+		for c, ok := bs.Min(); ok; c, ok = bs.Next(c) {
+			forward = append(forward, c)
+		}
+		for c, ok := bs.Max(); ok; c, ok = bs.Prev(c) {
+			backward = append([]byte{c}, backward...)
+		}
+		if string(forward) != string(members) || string(backward) != string(members) {
+			t.Fatalf("%q %q %q", members, forward, backward)
+		}
+		for from := range bytemap.Len {
+			wantNext, wantPrev := -1, -1
+			for _, c := range members {
+				if int(c) > from && wantNext == -1 {
+					wantNext = int(c)
+				}
+				if int(c) < from {
+					wantPrev = int(c)
+				}
+			}
+			if c, ok := bs.Next(byte(from)); ok != (wantNext != -1) || ok && int(c) != wantNext {
+				t.Fatal("Next", from, c, ok)
+			}
+			if c, ok := bs.Prev(byte(from)); ok != (wantPrev != -1) || ok && int(c) != wantPrev {
+				t.Fatal("Prev", from, c, ok)
+			}
+		}
+	})
+}
+
+func FuzzBitSet256SetAlgebra(f *testing.F) {
+	f.Add("", "")
+	f.Add("abc", "bcd")
+	f.Add("\x00\x3f\x40\x7f\x80\xbf\xc0\xff", "\x3f\x40\xc0")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		bfa, bfb := bytemap.Make(a).ToBitField(), bytemap.Make(b).ToBitField()
+		bsa, bsb := bfa.ToBitSet256(), bfb.ToBitSet256()
+		check := func(name string, got *bytemap.BitSet256, want *bytemap.BitField) {
+			t.Helper()
+			if !got.ToBitField().Equals(want) {
+				t.Fatalf("%s(%q, %q) = %v; want %v",
+					name, a, b, got.ToBool(), want.ToBool())
+			}
+		}
+		inPlace := func(op func(m *bytemap.BitSet256)) *bytemap.BitSet256 {
+			m := bsa.Clone()
+			op(m)
+			return m
+		}
+		check("Union", bsa.Union(bsb), bfa.Union(bfb))
+		check("UnionInPlace", inPlace(func(m *bytemap.BitSet256) {
+			m.UnionInPlace(bsb)
+		}), bfa.Union(bfb))
+		check("Intersect", bsa.Intersect(bsb), bfa.Intersect(bfb))
+		check("IntersectInPlace", inPlace(func(m *bytemap.BitSet256) {
+			m.IntersectInPlace(bsb)
+		}), bfa.Intersect(bfb))
+		check("Difference", bsa.Difference(bsb), bfa.Difference(bfb))
+		check("DifferenceInPlace", inPlace(func(m *bytemap.BitSet256) {
+			m.DifferenceInPlace(bsb)
+		}), bfa.Difference(bfb))
+		check("SymmetricDifference", bsa.SymmetricDifference(bsb), bfa.SymmetricDifference(bfb))
+		check("SymmetricDifferenceInPlace", inPlace(func(m *bytemap.BitSet256) {
+			m.SymmetricDifferenceInPlace(bsb)
+		}), bfa.SymmetricDifference(bfb))
+		check("Complement", bsa.Complement(), bfa.Complement())
+		check("ComplementInPlace", inPlace(func(m *bytemap.BitSet256) {
+			m.ComplementInPlace()
+		}), bfa.Complement())
+		check("Unmodified", bsa, bfa)
+		if bsa.IsSubsetOf(bsb) != bfa.IsSubsetOf(bfb) ||
+			bsa.IsSupersetOf(bsb) != bfa.IsSupersetOf(bfb) ||
+			bsa.Disjoint(bsb) != bfa.Disjoint(bfb) {
+			t.Fatal(a, b)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package bytemap_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/earthboundkid/bytemap/v2"
 )
@@ -39,3 +40,34 @@ func ExampleUnion() {
 	// false
 	// true
 }
+
+func ExampleBool_Fields() {
+	sep := bytemap.Make(" ,;")
+	for _, field := range sep.Fields("red, green;;blue ") {
+		fmt.Printf("%q\n", field)
+	}
+
+	key, value, _ := bytemap.Make(":=").Cut("name: value")
+	fmt.Printf("%q %q\n", key, strings.TrimSpace(value))
+	// Output:
+	// "red"
+	// "green"
+	// "blue"
+	// "name" "value"
+}
+
+func ExampleParseClass() {
+	ident, err := bytemap.ParseClass(`[\w$]`)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(ident.Contains("$foo_bar9"))
+	fmt.Println(ident.Contains("foo-bar"))
+
+	_, err = bytemap.ParseClass("[z-a]")
+	fmt.Println(err)
+	// Output:
+	// true
+	// false
+	// invalid character class at offset 1: invalid range "z-a"
+}
@@ -0,0 +1,49 @@
+//go:build go1.23 || goexperiment.rangefunc
+
+package bytemap
+
+import (
+	"iter"
+)
+
+// All returns a sequence of every byte and its value in m, in byte order.
+func (m *Bool) All() iter.Seq2[byte, bool] {
+	return func(yield func(byte, bool) bool) {
+		for i, v := range m {
+			if !yield(byte(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the members of m, in byte order.
+func (m *Bool) Keys() iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		for i, v := range m {
+			if v && !yield(byte(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the value for every byte in m, in byte order.
+func (m *Bool) Values() iter.Seq[bool] {
+	return func(yield func(bool) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect creates a bytemap.Bool containing the bytes in seq.
+func Collect(seq iter.Seq[byte]) *Bool {
+	var m Bool
+	for c := range seq {
+		m[c] = true
+	}
+	return &m
+}
@@ -0,0 +1,128 @@
+package bytemap
+
+// IndexAny returns the index of the first byte of s which is in m,
+// or -1 if there is none.
+func (m *Bool) IndexAny(s string) int {
+	return indexFunc(s, func(c byte) bool { return m[c] })
+}
+
+// IndexAnyBytes returns the index of the first byte of b which is in m,
+// or -1 if there is none.
+func (m *Bool) IndexAnyBytes(b []byte) int {
+	return indexFunc(b, func(c byte) bool { return m[c] })
+}
+
+// LastIndexAny returns the index of the last byte of s which is in m,
+// or -1 if there is none.
+func (m *Bool) LastIndexAny(s string) int {
+	return lastIndexFunc(s, func(c byte) bool { return m[c] })
+}
+
+// LastIndexAnyBytes returns the index of the last byte of b which is in m,
+// or -1 if there is none.
+func (m *Bool) LastIndexAnyBytes(b []byte) int {
+	return lastIndexFunc(b, func(c byte) bool { return m[c] })
+}
+
+// IndexNotIn returns the index of the first byte of s which is not in m,
+// or -1 if m contains all of s.
+func (m *Bool) IndexNotIn(s string) int {
+	return indexFunc(s, func(c byte) bool { return !m[c] })
+}
+
+// IndexNotInBytes returns the index of the first byte of b which is not in m,
+// or -1 if m contains all of b.
+func (m *Bool) IndexNotInBytes(b []byte) int {
+	return indexFunc(b, func(c byte) bool { return !m[c] })
+}
+
+// LastIndexNotIn returns the index of the last byte of s which is not in m,
+// or -1 if m contains all of s.
+func (m *Bool) LastIndexNotIn(s string) int {
+	return lastIndexFunc(s, func(c byte) bool { return !m[c] })
+}
+
+// LastIndexNotInBytes returns the index of the last byte of b which is not in m,
+// or -1 if m contains all of b.
+func (m *Bool) LastIndexNotInBytes(b []byte) int {
+	return lastIndexFunc(b, func(c byte) bool { return !m[c] })
+}
+
+// Count returns the number of bytes of s which are in m.
+func (m *Bool) Count(s string) int {
+	return countFunc(s, func(c byte) bool { return m[c] })
+}
+
+// CountBytes returns the number of bytes of b which are in m.
+func (m *Bool) CountBytes(b []byte) int {
+	return countFunc(b, func(c byte) bool { return m[c] })
+}
+
+// TrimLeft returns s without any leading bytes which are in m.
+func (m *Bool) TrimLeft(s string) string {
+	return trimLeft(s, m.IndexNotIn)
+}
+
+// TrimLeftBytes returns a subslice of b without any leading bytes which are in m.
+func (m *Bool) TrimLeftBytes(b []byte) []byte {
+	return trimLeft(b, m.IndexNotInBytes)
+}
+
+// TrimRight returns s without any trailing bytes which are in m.
+func (m *Bool) TrimRight(s string) string {
+	return trimRight(s, m.LastIndexNotIn)
+}
+
+// TrimRightBytes returns a subslice of b without any trailing bytes which are in m.
+func (m *Bool) TrimRightBytes(b []byte) []byte {
+	return trimRight(b, m.LastIndexNotInBytes)
+}
+
+// Trim returns s without any leading or trailing bytes which are in m.
+func (m *Bool) Trim(s string) string {
+	return m.TrimRight(m.TrimLeft(s))
+}
+
+// TrimBytes returns a subslice of b without any leading or trailing bytes which are in m.
+func (m *Bool) TrimBytes(b []byte) []byte {
+	return m.TrimRightBytes(m.TrimLeftBytes(b))
+}
+
+// Split slices s into the substrings separated by each byte in m.
+// If s contains no bytes in m, Split returns a slice containing only s.
+func (m *Bool) Split(s string) []string {
+	return split(s, m.IndexAny)
+}
+
+// SplitBytes slices b into the subslices separated by each byte in m.
+// If b contains no bytes in m, SplitBytes returns a slice containing only b.
+func (m *Bool) SplitBytes(b []byte) [][]byte {
+	return clip(split(b, m.IndexAnyBytes))
+}
+
+// Fields splits s around each run of bytes in m.
+// It never returns empty strings.
+func (m *Bool) Fields(s string) []string {
+	return fields(s, m.IndexAny, m.IndexNotIn)
+}
+
+// FieldsBytes splits b around each run of bytes in m.
+// It never returns empty slices.
+func (m *Bool) FieldsBytes(b []byte) [][]byte {
+	return clip(fields(b, m.IndexAnyBytes, m.IndexNotInBytes))
+}
+
+// Cut slices s around the first byte in m,
+// returning the text before and after it.
+// If s contains no bytes in m, Cut returns s, "", false.
+func (m *Bool) Cut(s string) (before, after string, found bool) {
+	return cut(s, m.IndexAny)
+}
+
+// CutBytes slices b around the first byte in m,
+// returning the subslices before and after it.
+// If b contains no bytes in m, CutBytes returns b, an empty slice, false.
+func (m *Bool) CutBytes(b []byte) (before, after []byte, found bool) {
+	before, after, found = cut(b, m.IndexAnyBytes)
+	return before[:len(before):len(before)], after, found
+}
@@ -0,0 +1,137 @@
+package bytemap
+
+import (
+	"fmt"
+)
+
+// Byte is an array backed map from byte to byte,
+// suitable for use as a translation table.
+// The zero value maps every byte to 0;
+// use Identity or Translate to create a useful table.
+type Byte [Len]byte
+
+// Identity creates a bytemap.Byte that maps every byte to itself.
+func Identity() *Byte {
+	var m Byte
+	for i := range m {
+		m[i] = byte(i)
+	}
+	return &m
+}
+
+// Translate creates a bytemap.Byte
+// that maps the bytes in from to the corresponding bytes in to,
+// in the manner of the tr command.
+// Bytes not in from map to themselves.
+//
+// Both from and to may contain ranges like "a-z".
+// A hyphen at the start or end of a set is treated literally.
+// If to is shorter than from, its last byte is repeated to pad it out.
+// If a byte appears more than once in from, its last mapping wins.
+// If a range is invalid or to is empty while from is not, it panics.
+func Translate(from, to string) *Byte {
+	src := expandTr(from)
+	dst := expandTr(to)
+	if len(dst) == 0 && len(src) != 0 {
+		panic(fmt.Errorf("invalid translation: no replacement for %q", from))
+	}
+	m := Identity()
+	for i, c := range src {
+		if i < len(dst) {
+			m[c] = dst[i]
+		} else {
+			m[c] = dst[len(dst)-1]
+		}
+	}
+	return m
+}
+
+// expandTr expands the ranges in a tr style set.
+func expandTr(set string) []byte {
+	var b []byte
+	for i := 0; i < len(set); i++ {
+		if i+2 < len(set) && set[i+1] == '-' {
+			start, end := set[i], set[i+2]
+			if end < start {
+				panic(fmt.Errorf("invalid range: %d - %d", start, end))
+			}
+			for c := int(start); c <= int(end); c++ {
+				b = append(b, byte(c))
+			}
+			i += 2
+			continue
+		}
+		b = append(b, set[i])
+	}
+	return b
+}
+
+// Apply translates each byte of b in place.
+func (m *Byte) Apply(b []byte) {
+	for i, c := range b {
+		b[i] = m[c]
+	}
+}
+
+// ApplyString returns a copy of s with each byte translated.
+func (m *Byte) ApplyString(s string) string {
+	b := []byte(s)
+	m.Apply(b)
+	return string(b)
+}
+
+// Then returns a Byte which translates with m and then with other.
+func (m *Byte) Then(other *Byte) *Byte {
+	var m2 Byte
+	for i, c := range m {
+		m2[i] = other[c]
+	}
+	return &m2
+}
+
+// Inverse returns the Byte which undoes the translation of m.
+// If m is not a bijection, it returns nil, false.
+func (m *Byte) Inverse() (*Byte, bool) {
+	var (
+		m2   Byte
+		seen Bool
+	)
+	for i, c := range m {
+		if seen[c] {
+			return nil, false
+		}
+		seen[c] = true
+		m2[c] = byte(i)
+	}
+	return &m2, true
+}
+
+// ToMap makes a map[byte]byte from the bytemap.
+func (m *Byte) ToMap() map[byte]byte {
+	m2 := make(map[byte]byte)
+	for i := range m {
+		m2[byte(i)] = m[i]
+	}
+	return m2
+}
+
+// Equals reports if two Bytes are equal.
+func (m *Byte) Equals(other *Byte) bool {
+	return *m == *other
+}
+
+// Set sets one byte in the Byte byte map.
+func (m *Byte) Set(key byte, value byte) {
+	m[key] = value
+}
+
+// Get looks up one byte in the Byte byte map.
+func (m *Byte) Get(key byte) byte {
+	return m[key]
+}
+
+// Clone copies m.
+func (m *Byte) Clone() *Byte {
+	m2 := *m
+	return &m2
+}
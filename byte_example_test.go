@@ -0,0 +1,23 @@
+package bytemap_test
+
+import (
+	"fmt"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func ExampleTranslate() {
+	rot13 := bytemap.Translate("a-zA-Z", "n-za-mN-ZA-M")
+	fmt.Println(rot13.ApplyString("Hello, world!"))
+
+	unrot13, ok := rot13.Inverse()
+	fmt.Println(unrot13.ApplyString("Uryyb, jbeyq!"), ok)
+
+	upper := bytemap.Translate("a-z", "A-Z")
+	spaces := bytemap.Translate("_-", " ")
+	fmt.Println(upper.Then(spaces).ApplyString("snake_case-kebab"))
+	// Output:
+	// Uryyb, jbeyq!
+	// Hello, world! true
+	// SNAKE CASE KEBAB
+}
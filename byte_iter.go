@@ -0,0 +1,29 @@
+//go:build go1.23 || goexperiment.rangefunc
+
+package bytemap
+
+import (
+	"iter"
+)
+
+// All returns a sequence of every byte and its translation in m, in byte order.
+func (m *Byte) All() iter.Seq2[byte, byte] {
+	return func(yield func(byte, byte) bool) {
+		for i, v := range m {
+			if !yield(byte(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the translation for every byte in m, in byte order.
+func (m *Byte) Values() iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
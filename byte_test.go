@@ -0,0 +1,112 @@
+package bytemap_test
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestTranslate(t *testing.T) {
+	for _, tc := range []struct {
+		from, to, in, want string
+	}{
+		{"", "", "hello", "hello"},
+		{"a-z", "A-Z", "Hello, world!", "HELLO, WORLD!"},
+		{"a-zA-Z", "n-za-mN-ZA-M", "Hello, world!", "Uryyb, jbeyq!"},
+		{"abc", "x", "aabbccdd", "xxxxxxdd"},
+		{"abc", "xy", "abcd", "xyyd"},
+		{"a", "xyz", "abc", "xbc"},
+		{"-_", "_-", "snake_case-kebab", "snake-case_kebab"},
+		{"a-", "b+", "a-b", "b+b"},
+		{"aa", "xy", "a", "y"},
+	} {
+		m := bytemap.Translate(tc.from, tc.to)
+		if got := m.ApplyString(tc.in); got != tc.want {
+			t.Errorf("Translate(%q, %q).ApplyString(%q) = %q; want %q",
+				tc.from, tc.to, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestTranslatePanics(t *testing.T) {
+	for _, tc := range []struct{ from, to string }{
+		{"z-a", "a"},
+		{"a", "z-a"},
+		{"a", ""},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Translate(%q, %q) did not panic", tc.from, tc.to)
+				}
+			}()
+			bytemap.Translate(tc.from, tc.to)
+		}()
+	}
+}
+
+func TestByteInverse(t *testing.T) {
+	rot13 := bytemap.Translate("a-zA-Z", "n-za-mN-ZA-M")
+	inv, ok := rot13.Inverse()
+	if !ok {
+		t.Fatal("rot13 should be invertible")
+	}
+	if !inv.Equals(rot13) {
+		t.Fatal("rot13 should be its own inverse")
+	}
+	if !rot13.Then(rot13).Equals(bytemap.Identity()) {
+		t.Fatal("rot13 twice should be the identity")
+	}
+	upper := bytemap.Translate("a-z", "A-Z")
+	if inv, ok := upper.Inverse(); ok || inv != nil {
+		t.Fatal("upper should not be invertible")
+	}
+}
+
+func FuzzByte(f *testing.F) {
+	f.Add("", "a-z", "A-Z")
+	f.Add("Hello, world!", "a-z", "A-Z")
+	f.Add("Hello, world!", "lo", "01")
+	f.Fuzz(func(t *testing.T, s, from, to string) {
+		if strings.Contains(from, "-") || strings.Contains(to, "-") || to == "" {
+			t.Skip()
+		}
+		m := bytemap.Translate(from, to)
+		naive := make(map[byte]byte)
+		for i := 0; i < bytemap.Len; i++ {
+			naive[byte(i)] = byte(i)
+		}
+		for i := range len(from) {
+			naive[from[i]] = to[min(i, len(to)-1)]
+		}
+		if !maps.Equal(m.ToMap(), naive) {
+			t.Fatal(from, to)
+		}
+		for i := 0; i < bytemap.Len; i++ {
+			if m.Get(byte(i)) != naive[byte(i)] {
+				t.Fatal(i, m)
+			}
+		}
+		b := []byte(s)
+		m.Apply(b)
+		for i := range len(s) {
+			if b[i] != naive[s[i]] {
+				t.Fatal(s, b)
+			}
+		}
+		if m.ApplyString(s) != string(b) {
+			t.Fatal(s, b)
+		}
+		m2 := m.Clone()
+		m2.Set(0, m2.Get(0)+1)
+		if m2.Equals(m) {
+			t.Fatal(m2)
+		}
+		if !m.Then(bytemap.Identity()).Equals(m) ||
+			!bytemap.Identity().Then(m).Equals(m) {
+			t.Fatal(m)
+		}
+	})
+}
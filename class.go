@@ -0,0 +1,261 @@
+package bytemap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError describes a problem parsing a character class.
+type SyntaxError struct {
+	Msg    string // description of the error
+	Offset int    // byte offset in the input where the error was found
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid character class at offset %d: %s", e.Offset, e.Msg)
+}
+
+// ParseClass creates a bytemap.Bool from a regular expression style
+// character class, such as "[a-zA-Z0-9_\-]".
+//
+// The class may be negated with a leading ^ and may contain
+// literal bytes, ranges like a-z,
+// POSIX classes like [:digit:],
+// the Perl shorthands \d, \w, \s, \D, \W, and \S,
+// and the escapes \a, \f, \t, \n, \r, \v, and \xHH.
+// Any punctuation may be escaped with a backslash.
+// A ] at the start of the class or a - at the start or end is literal.
+// As in regexp, a [ which does not begin a POSIX class is literal.
+// A lone Perl shorthand such as "\d" is also accepted.
+//
+// If s is not a valid class, the error is a *SyntaxError.
+func ParseClass(s string) (*Bool, error) {
+	p := classParser{s: s}
+	if strings.HasPrefix(s, `\`) {
+		m, ok := p.shorthand()
+		if !ok {
+			return nil, p.errorf(0, "expected [ or shorthand class")
+		}
+		if p.pos != len(s) {
+			return nil, p.errorf(p.pos, "unexpected text after class")
+		}
+		return m.Clone(), nil
+	}
+	if !p.consume("[") {
+		return nil, p.errorf(0, "expected [")
+	}
+	negate := p.consume("^")
+	var m Bool
+	first := true
+	for {
+		if p.pos >= len(s) {
+			return nil, p.errorf(p.pos, "missing closing ]")
+		}
+		if s[p.pos] == ']' && !first {
+			p.pos++
+			break
+		}
+		first = false
+		if ok, err := p.posix(&m); err != nil {
+			return nil, err
+		} else if ok {
+			continue
+		}
+		if sh, ok := p.shorthand(); ok {
+			m = *Union(&m, sh)
+			continue
+		}
+		start := p.pos
+		lo, err := p.char()
+		if err != nil {
+			return nil, err
+		}
+		// a - is a range unless it is the last thing in the class
+		if !strings.HasPrefix(s[p.pos:], "-") || strings.HasPrefix(s[p.pos:], "-]") {
+			m[lo] = true
+			continue
+		}
+		p.pos++
+		if _, ok := p.clone().shorthand(); ok {
+			return nil, p.errorf(p.pos, "invalid range end")
+		}
+		hi, err := p.char()
+		if err != nil {
+			return nil, err
+		}
+		if hi < lo {
+			return nil, p.errorf(start, "invalid range %q", s[start:p.pos])
+		}
+		for c := int(lo); c <= int(hi); c++ {
+			m[c] = true
+		}
+	}
+	if p.pos != len(s) {
+		return nil, p.errorf(p.pos, "unexpected text after class")
+	}
+	if negate {
+		return m.Invert(), nil
+	}
+	return &m, nil
+}
+
+// MustParseClass is like ParseClass but panics if s is not a valid class.
+func MustParseClass(s string) *Bool {
+	m, err := ParseClass(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+type classParser struct {
+	s   string
+	pos int
+}
+
+func (p *classParser) clone() *classParser {
+	p2 := *p
+	return &p2
+}
+
+func (p *classParser) errorf(offset int, format string, args ...any) error {
+	return &SyntaxError{fmt.Sprintf(format, args...), offset}
+}
+
+func (p *classParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.s[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+// posix parses a POSIX class like [:digit:], if there is one,
+// and adds it to m.
+// As in regexp, a [: with no closing :] is not a class,
+// and its [ is literal.
+func (p *classParser) posix(m *Bool) (ok bool, err error) {
+	start := p.pos
+	if !strings.HasPrefix(p.s[start:], "[:") {
+		return false, nil
+	}
+	end := strings.Index(p.s[start+2:], ":]")
+	if end == -1 {
+		return false, nil
+	}
+	name := p.s[start+2 : start+2+end]
+	class := posixClasses[name]
+	if class == nil {
+		return false, p.errorf(start, "unknown POSIX class %q", name)
+	}
+	*m = *Union(m, class)
+	p.pos = start + 2 + end + 2
+	return true, nil
+}
+
+// shorthand parses a Perl shorthand class like \d, if there is one.
+// The returned Bool may be shared and must not be modified.
+func (p *classParser) shorthand() (m *Bool, ok bool) {
+	if !strings.HasPrefix(p.s[p.pos:], `\`) || p.pos+1 >= len(p.s) {
+		return nil, false
+	}
+	switch p.s[p.pos+1] {
+	case 'd':
+		m = posixClasses["digit"]
+	case 'w':
+		m = posixClasses["word"]
+	case 's':
+		m = perlSpace
+	case 'D':
+		m = posixClasses["digit"].Invert()
+	case 'W':
+		m = posixClasses["word"].Invert()
+	case 'S':
+		m = perlSpace.Invert()
+	default:
+		return nil, false
+	}
+	p.pos += 2
+	return m, true
+}
+
+// char parses a single literal or escaped byte.
+func (p *classParser) char() (byte, error) {
+	if p.pos >= len(p.s) {
+		return 0, p.errorf(p.pos, "missing closing ]")
+	}
+	c := p.s[p.pos]
+	if c != '\\' {
+		p.pos++
+		return c, nil
+	}
+	start := p.pos
+	if p.pos+1 >= len(p.s) {
+		return 0, p.errorf(start, "trailing backslash")
+	}
+	c = p.s[p.pos+1]
+	p.pos += 2
+	switch c {
+	case 'a':
+		return '\a', nil
+	case 'f':
+		return '\f', nil
+	case 't':
+		return '\t', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 'v':
+		return '\v', nil
+	case 'x':
+		if p.pos+2 > len(p.s) {
+			return 0, p.errorf(start, "invalid hex escape")
+		}
+		hi, ok1 := unhex(p.s[p.pos])
+		lo, ok2 := unhex(p.s[p.pos+1])
+		if !ok1 || !ok2 {
+			return 0, p.errorf(start, "invalid hex escape")
+		}
+		p.pos += 2
+		return hi<<4 | lo, nil
+	}
+	if c < 0x80 && !posixClasses["alnum"][c] {
+		return c, nil
+	}
+	return 0, p.errorf(start, "invalid escape %q", p.s[start:p.pos])
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// posixClasses holds the POSIX classes by name.
+// They are shared, so they must not be modified.
+var posixClasses = map[string]*Bool{
+	"alnum":  Union(Range('0', '9'), Range('A', 'Z'), Range('a', 'z')),
+	"alpha":  Union(Range('A', 'Z'), Range('a', 'z')),
+	"ascii":  Range(0, 0x7F),
+	"blank":  Make(" \t"),
+	"cntrl":  Union(Range(0, 0x1F), Make("\x7F")),
+	"digit":  Range('0', '9'),
+	"graph":  Range('!', '~'),
+	"lower":  Range('a', 'z'),
+	"print":  Range(' ', '~'),
+	"punct":  Make("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"),
+	"space":  Make(" \t\n\v\f\r"),
+	"upper":  Range('A', 'Z'),
+	"word":   Union(Range('0', '9'), Range('A', 'Z'), Range('a', 'z'), Make("_")),
+	"xdigit": Union(Range('0', '9'), Range('A', 'F'), Range('a', 'f')),
+}
+
+// perlSpace is the Perl \s class, which does not include \v.
+var perlSpace = Make("\t\n\f\r ")
@@ -0,0 +1,179 @@
+package bytemap_test
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestParseClass(t *testing.T) {
+	for _, tc := range []struct {
+		class string
+		want  *bytemap.Bool
+	}{
+		{"[]", nil},
+		{"[a]", bytemap.Make("a")},
+		{"[abc]", bytemap.Make("abc")},
+		{"[a-c]", bytemap.Make("abc")},
+		{"[a-cx-z]", bytemap.Make("abcxyz")},
+		{`[a-zA-Z0-9_\-]`, bytemap.Union(
+			bytemap.Range('a', 'z'), bytemap.Range('A', 'Z'),
+			bytemap.Range('0', '9'), bytemap.Make("_-"))},
+		{"[-a]", bytemap.Make("-a")},
+		{"[a-]", bytemap.Make("-a")},
+		{"[]a]", bytemap.Make("]a")},
+		{"[^]a]", bytemap.Make("]a").Invert()},
+		{"[^a]", bytemap.Make("a").Invert()},
+		{"[a^]", bytemap.Make("a^")},
+		{`[\n\t\r\f\v\a]`, bytemap.Make("\n\t\r\f\v\a")},
+		{`[\x00-\x1f\x7F]`, bytemap.Union(bytemap.Range(0, 0x1f), bytemap.Make("\x7f"))},
+		{`[\x80-\xff]`, bytemap.Range(0x80, 0xff)},
+		{`[\]\[\\\^\-\.]`, bytemap.Make(`][\^-.`)},
+		{"[[:digit:]]", bytemap.Range('0', '9')},
+		{"[[:xdigit:]]", bytemap.Union(
+			bytemap.Range('0', '9'), bytemap.Range('a', 'f'), bytemap.Range('A', 'F'))},
+		{"[^[:print:]]", bytemap.Range(' ', '~').Invert()},
+		{"[[:upper:][:digit:]_]", bytemap.Union(
+			bytemap.Range('A', 'Z'), bytemap.Range('0', '9'), bytemap.Make("_"))},
+		{"[[:]", bytemap.Make("[:")},
+		{"[a[:]", bytemap.Make("a[:")},
+		{"[[:digit]", bytemap.Make("[:digt")},
+		{"[!-[:]", bytemap.Range('!', '[')},
+		{`\d`, bytemap.Range('0', '9')},
+		{`\D`, bytemap.Range('0', '9').Invert()},
+		{`[\d.]`, bytemap.Make("0123456789.")},
+		{`[\s]`, bytemap.Make(" \t\n\f\r")},
+		{`\S`, bytemap.Make(" \t\n\f\r").Invert()},
+		{`[\w$]`, bytemap.Union(
+			bytemap.Range('a', 'z'), bytemap.Range('A', 'Z'),
+			bytemap.Range('0', '9'), bytemap.Make("_$"))},
+		{`[^\W]`, bytemap.Union(
+			bytemap.Range('a', 'z'), bytemap.Range('A', 'Z'),
+			bytemap.Range('0', '9'), bytemap.Make("_"))},
+	} {
+		got, err := bytemap.ParseClass(tc.class)
+		if tc.want == nil {
+			// "[]" is an unterminated class containing ]
+			if err == nil {
+				t.Errorf("ParseClass(%q) = %v; want error", tc.class, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseClass(%q): %v", tc.class, err)
+			continue
+		}
+		if !got.Equals(tc.want) {
+			t.Errorf("ParseClass(%q) = %v; want %v", tc.class, got, tc.want)
+		}
+	}
+}
+
+func TestParseClassShorthandIsCopy(t *testing.T) {
+	bytemap.MustParseClass(`\d`).Set('x', true)
+	if bytemap.MustParseClass(`\d`).Get('x') {
+		t.Fatal("shorthand class was shared")
+	}
+}
+
+func TestParseClassError(t *testing.T) {
+	for _, tc := range []struct {
+		class  string
+		offset int
+	}{
+		{"", 0},
+		{"abc", 0},
+		{"[abc", 4},
+		{"[abc]d", 5},
+		{`\dd`, 2},
+		{`\q`, 0},
+		{"[z-a]", 1},
+		{`[a-\d]`, 3},
+		{"[a-[:digit:]]", 1},
+		{"[[:nope:]]", 1},
+		{`[\q]`, 1},
+		{`[\x]`, 1},
+		{`[\xZZ]`, 1},
+		{`[\x1]`, 1},
+		{`[abc\`, 4},
+		{"[\\\xff]", 1},
+	} {
+		_, err := bytemap.ParseClass(tc.class)
+		var se *bytemap.SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("ParseClass(%q): got %v; want SyntaxError", tc.class, err)
+			continue
+		}
+		if se.Offset != tc.offset {
+			t.Errorf("ParseClass(%q): got %v; want offset %d", tc.class, err, tc.offset)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustParseClass did not panic")
+		}
+	}()
+	bytemap.MustParseClass("[")
+}
+
+func FuzzParseClass(f *testing.F) {
+	f.Add("[a-z]")
+	f.Add("[^a-zA-Z0-9_]")
+	f.Add(`[\d\s.,;]`)
+	f.Add("[[:alpha:][:punct:]]")
+	f.Add(`[\x00-\x7f]`)
+	f.Add(`[\a\00]`)
+	f.Add("[[:]")
+	f.Fuzz(func(t *testing.T, class string) {
+		m, err := bytemap.ParseClass(class)
+		re, reErr := regexp.Compile(`^` + class + `$`)
+		if reErr != nil {
+			return
+		}
+		if err != nil {
+			if isSimpleClass(class) {
+				t.Fatalf("ParseClass(%q): %v; regexp accepts it", class, err)
+			}
+			return
+		}
+		// Go regexps match runes, so only compare ASCII
+		for c := range 0x80 {
+			if re.MatchString(string(rune(c))) != m[c] {
+				t.Fatalf("class %q disagrees with regexp on %q", class, c)
+			}
+		}
+	})
+}
+
+// unsupportedClassSyntax matches regexp class syntax which ParseClass lacks:
+// octal and Unicode escapes and negated POSIX classes.
+var unsupportedClassSyntax = regexp.MustCompile(`\\([0-9pP]|x\{)|\[:\^`)
+
+// isSimpleClass reports whether class is a single ASCII bracket class
+// using only syntax which ParseClass supports.
+func isSimpleClass(class string) bool {
+	for _, c := range []byte(class) {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	if unsupportedClassSyntax.MatchString(class) {
+		return false
+	}
+	re, err := syntax.Parse(class, syntax.Perl)
+	if err != nil || !strings.HasPrefix(class, "[") {
+		return false
+	}
+	switch re.Op {
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return true
+	case syntax.OpLiteral:
+		return len(re.Rune) == 1
+	}
+	return false
+}
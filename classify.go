@@ -0,0 +1,159 @@
+package bytemap
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/earthboundkid/bytemap/v2/internal/textclass"
+)
+
+// Class is a broad category of content, as determined by Classify.
+type Class int
+
+// Classes of content.
+const (
+	// Text is text in ASCII or a single-byte encoding such as Latin-1.
+	Text Class = iota
+	// UTF8Text is text with non-ASCII characters which is valid UTF-8.
+	UTF8Text
+	// Binary is data which is not text.
+	Binary
+	// Compressed is binary data with entropy so high
+	// that it is probably compressed, encrypted, or random.
+	Compressed
+)
+
+var classNames = [...]string{
+	Text:       "text",
+	UTF8Text:   "UTF-8 text",
+	Binary:     "binary",
+	Compressed: "compressed",
+}
+
+func (c Class) String() string {
+	if c < 0 || int(c) >= len(classNames) {
+		return fmt.Sprintf("Class(%d)", int(c))
+	}
+	return classNames[c]
+}
+
+// IsText reports whether c is Text or UTF8Text.
+func (c Class) IsText() bool {
+	return c == Text || c == UTF8Text
+}
+
+// Default settings for a Classifier.
+const (
+	// DefaultSampleSize is the number of bytes Git checks
+	// when deciding whether a file is binary.
+	DefaultSampleSize = 8000
+	// DefaultMaxControl allows one control character in a hundred,
+	// enough for the odd form feed or stray escape sequence.
+	DefaultMaxControl = 0.01
+	// DefaultMinEntropy is close to the 8 bits per byte of random data.
+	// Compressed formats typically measure above it,
+	// while uncompressed binary formats fall well below it.
+	DefaultMinEntropy = 7.5
+)
+
+// Classifier decides whether content is text or binary.
+// The zero value uses the default settings.
+type Classifier struct {
+	// SampleSize is the number of bytes IsText reads.
+	// If it is 0 or less, DefaultSampleSize is used.
+	SampleSize int
+	// AllowNUL treats NUL bytes as text, as in UTF-16,
+	// instead of as a sure sign of binary data.
+	AllowNUL bool
+	// MaxControl is the largest fraction of bytes in text
+	// which may be control characters other than whitespace.
+	// If it is 0, DefaultMaxControl is used.
+	// Use a negative value to allow none.
+	MaxControl float64
+	// MinEntropy is the entropy in bits per byte at or above which
+	// binary data is classified as Compressed.
+	// If it is 0, DefaultMinEntropy is used.
+	// Because entropy cannot exceed log2 of the number of bytes counted,
+	// a sample of fewer than 256 bytes is unlikely to be Compressed.
+	MinEntropy float64
+}
+
+func (cl *Classifier) sampleSize() int {
+	if cl.SampleSize <= 0 {
+		return DefaultSampleSize
+	}
+	return cl.SampleSize
+}
+
+func (cl *Classifier) maxControl() float64 {
+	if cl.MaxControl == 0 {
+		return DefaultMaxControl
+	}
+	return max(cl.MaxControl, 0)
+}
+
+func (cl *Classifier) minEntropy() float64 {
+	if cl.MinEntropy == 0 {
+		return DefaultMinEntropy
+	}
+	return cl.MinEntropy
+}
+
+// Classify decides which Class of content has the byte counts in m.
+// Content with NUL bytes or too many control characters is binary.
+// Binary content with high entropy is Compressed.
+// Text is UTF8Text if it has non-ASCII bytes
+// which are consistent with UTF-8.
+// Counts with no positive values are Text.
+func (cl *Classifier) Classify(m *Int) Class {
+	counts := (*[Len]int)(m)
+	n := 0
+	for _, v := range counts {
+		n += max(v, 0)
+	}
+	if n == 0 {
+		return Text
+	}
+	control := textclass.Sum(counts, textclass.Control)
+	if !cl.AllowNUL && m[0] > 0 || float64(control) > cl.maxControl()*float64(n) {
+		if m.Entropy() >= cl.minEntropy() {
+			return Compressed
+		}
+		return Binary
+	}
+	if textclass.Sum(counts, textclass.High) == 0 {
+		return Text
+	}
+	if textclass.UTF8Mismatches(counts) == 0 {
+		return UTF8Text
+	}
+	return Text
+}
+
+// IsText reads up to SampleSize bytes from r
+// and reports whether they are classified as text.
+// A multibyte UTF-8 character cut off at the end of the sample
+// does not keep the sample from counting as text.
+func (cl *Classifier) IsText(r io.Reader) (bool, error) {
+	var m Int
+	if _, err := io.Copy(&m, io.LimitReader(r, int64(cl.sampleSize()))); err != nil {
+		return false, err
+	}
+	return cl.Classify(&m).IsText(), nil
+}
+
+// Classify decides which Class of content has the byte counts in m
+// using the default Classifier settings.
+func Classify(m *Int) Class {
+	var cl Classifier
+	return cl.Classify(m)
+}
+
+// IsText reads up to limit bytes from r
+// and reports whether they are text
+// using the default Classifier settings.
+// If limit is 0 or less, DefaultSampleSize is used.
+func IsText(r io.Reader, limit int) (bool, error) {
+	cl := Classifier{SampleSize: limit}
+	return cl.IsText(r)
+}
@@ -0,0 +1,133 @@
+package bytemap_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func classifyFixtures(t *testing.T) map[string][]byte {
+	t.Helper()
+	mobyDick, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(mobyDick)
+	zw.Close()
+	random := make([]byte, 10_000)
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range random {
+		random[i] = byte(r.Uint32())
+	}
+	// Little endian integers, like a binary file format
+	var ints []byte
+	for i := range 2000 {
+		ints = binary.LittleEndian.AppendUint32(ints, uint32(i*i))
+	}
+	return map[string][]byte{
+		"empty":     nil,
+		"ascii":     []byte(strings.Repeat("package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n", 20)),
+		"utf-8":     []byte(strings.Repeat("naïve café — “quoted” 日本語\n", 20)),
+		"latin-1":   []byte(strings.Repeat("na\xefve caf\xe9\n", 20)),
+		"moby-dick": mobyDick,
+		"gzip":      gz.Bytes(),
+		"random":    random,
+		"ints":      ints,
+		"controls":  []byte(strings.Repeat("\x01\x02\x03text", 20)),
+		"utf-16":    []byte(strings.Repeat("t\x00e\x00x\x00t\x00\n\x00", 20)),
+	}
+}
+
+func TestClassify(t *testing.T) {
+	fixtures := classifyFixtures(t)
+	for name, want := range map[string]bytemap.Class{
+		"empty":     bytemap.Text,
+		"ascii":     bytemap.Text,
+		"utf-8":     bytemap.UTF8Text,
+		"latin-1":   bytemap.Text,
+		"moby-dick": bytemap.UTF8Text,
+		"gzip":      bytemap.Compressed,
+		"random":    bytemap.Compressed,
+		"ints":      bytemap.Binary,
+		"controls":  bytemap.Binary,
+		"utf-16":    bytemap.Binary,
+	} {
+		var m bytemap.Int
+		m.Write(fixtures[name])
+		if got := bytemap.Classify(&m); got != want {
+			t.Errorf("Classify(%s) = %v; want %v", name, got, want)
+		}
+	}
+}
+
+func TestClassifier(t *testing.T) {
+	fixtures := classifyFixtures(t)
+	count := func(name string) *bytemap.Int {
+		var m bytemap.Int
+		m.Write(fixtures[name])
+		return &m
+	}
+	cl := bytemap.Classifier{AllowNUL: true}
+	if got := cl.Classify(count("utf-16")); got != bytemap.Text {
+		t.Errorf("AllowNUL: got %v", got)
+	}
+	cl = bytemap.Classifier{MaxControl: 0.5}
+	if got := cl.Classify(count("controls")); got != bytemap.Text {
+		t.Errorf("MaxControl: got %v", got)
+	}
+	cl = bytemap.Classifier{MaxControl: -1}
+	var m bytemap.Int
+	m.WriteString("text\x01")
+	if got := cl.Classify(&m); got != bytemap.Binary {
+		t.Errorf("MaxControl: got %v", got)
+	}
+	cl = bytemap.Classifier{MinEntropy: 8.1}
+	if got := cl.Classify(count("random")); got != bytemap.Binary {
+		t.Errorf("MinEntropy: got %v", got)
+	}
+	if got := bytemap.Class(10).String(); got != "Class(10)" {
+		t.Error(got)
+	}
+}
+
+func TestIsText(t *testing.T) {
+	fixtures := classifyFixtures(t)
+	for name, want := range map[string]bool{
+		"ascii":     true,
+		"utf-8":     true,
+		"moby-dick": true,
+		"gzip":      false,
+		"ints":      false,
+	} {
+		got, err := bytemap.IsText(bytes.NewReader(fixtures[name]), 0)
+		if err != nil || got != want {
+			t.Errorf("IsText(%s) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	// Only the sample is read
+	data := append([]byte(strings.Repeat("text", 100)), 0)
+	if ok, err := bytemap.IsText(bytes.NewReader(data), 400); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	if ok, err := bytemap.IsText(bytes.NewReader(data), 401); ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	// A character cut off by the sample is still text
+	if ok, err := bytemap.IsText(strings.NewReader("日本語"), 4); !ok || err != nil {
+		t.Fatal(ok, err)
+	}
+	errBoom := errors.New("boom")
+	if _, err := bytemap.IsText(iotest.ErrReader(errBoom), 0); err != errBoom {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,188 @@
+package bytemap
+
+import (
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// AtomicInt is an array backed map from byte to integer
+// which is safe for concurrent use.
+// The zero value is ready to use.
+type AtomicInt struct {
+	counts [Len]atomic.Int64
+}
+
+// atomicBatch is the write length at which AtomicInt
+// counts into a local Int before adding to the shared counts.
+const atomicBatch = 256
+
+func (m *AtomicInt) add(local *Int) {
+	for i, n := range local {
+		if n != 0 {
+			m.counts[i].Add(int64(n))
+		}
+	}
+}
+
+var _ io.Writer = (*AtomicInt)(nil)
+
+// Write satisfies io.Writer.
+func (m *AtomicInt) Write(p []byte) (int, error) {
+	if len(p) < atomicBatch {
+		for _, c := range p {
+			m.counts[c].Add(1)
+		}
+		return len(p), nil
+	}
+	var local Int
+	local.Write(p)
+	m.add(&local)
+	return len(p), nil
+}
+
+var _ io.StringWriter = (*AtomicInt)(nil)
+
+// WriteString satisfies io.StringWriter.
+func (m *AtomicInt) WriteString(s string) (n int, err error) {
+	if len(s) < atomicBatch {
+		for _, c := range []byte(s) {
+			m.counts[c].Add(1)
+		}
+		return len(s), nil
+	}
+	var local Int
+	local.WriteString(s)
+	m.add(&local)
+	return len(s), nil
+}
+
+// Add adds delta to the count for key.
+func (m *AtomicInt) Add(key byte, delta int) {
+	m.counts[key].Add(int64(delta))
+}
+
+// Get looks up the count for key.
+func (m *AtomicInt) Get(key byte) int {
+	return int(m.counts[key].Load())
+}
+
+// Snapshot copies the counts in m into a new Int.
+// Each count is read atomically,
+// but writes in progress may be partly reflected.
+func (m *AtomicInt) Snapshot() *Int {
+	var m2 Int
+	for i := range m.counts {
+		m2[i] = int(m.counts[i].Load())
+	}
+	return &m2
+}
+
+// Reset sets all counts in m to zero.
+func (m *AtomicInt) Reset() {
+	for i := range m.counts {
+		m.counts[i].Store(0)
+	}
+}
+
+// shard is one of the Ints a ShardedInt spreads writes across.
+// Its 2 KiB of counts already keep neighboring mutexes
+// off the same cache line.
+type shard struct {
+	mu     sync.Mutex
+	counts Int
+}
+
+// ShardedInt is an array backed map from byte to integer
+// which is safe for concurrent use.
+// It spreads writes across as many Ints as there are processors,
+// giving each write the next shard which is not locked,
+// and merges them on Snapshot.
+// Shards are not tied to processors,
+// but contention stays low while writers do not outnumber shards.
+// The zero value is ready to use.
+type ShardedInt struct {
+	once   sync.Once
+	shards []shard
+	next   atomic.Uint32
+}
+
+// NewShardedInt returns a ShardedInt with as many shards
+// as runtime.GOMAXPROCS reports processors.
+func NewShardedInt() *ShardedInt {
+	m := new(ShardedInt)
+	m.init()
+	return m
+}
+
+// init allocates the shards of m on first use
+// and returns them.
+func (m *ShardedInt) init() []shard {
+	m.once.Do(func() {
+		m.shards = make([]shard, runtime.GOMAXPROCS(0))
+	})
+	return m.shards
+}
+
+// lock returns a locked shard,
+// preferring one that is not in use by another writer.
+func (m *ShardedInt) lock() *shard {
+	shards := m.init()
+	n := uint32(len(shards))
+	start := m.next.Add(1)
+	for i := range n {
+		s := &shards[(start+i)%n]
+		if s.mu.TryLock() {
+			return s
+		}
+	}
+	s := &shards[start%n]
+	s.mu.Lock()
+	return s
+}
+
+var _ io.Writer = (*ShardedInt)(nil)
+
+// Write satisfies io.Writer.
+func (m *ShardedInt) Write(p []byte) (int, error) {
+	s := m.lock()
+	defer s.mu.Unlock()
+	return s.counts.Write(p)
+}
+
+var _ io.StringWriter = (*ShardedInt)(nil)
+
+// WriteString satisfies io.StringWriter.
+func (m *ShardedInt) WriteString(str string) (n int, err error) {
+	s := m.lock()
+	defer s.mu.Unlock()
+	return s.counts.WriteString(str)
+}
+
+// Snapshot merges the counts of all shards into a new Int.
+// Each write is either fully reflected or not at all.
+func (m *ShardedInt) Snapshot() *Int {
+	var m2 Int
+	shards := m.init()
+	for i := range shards {
+		s := &shards[i]
+		s.mu.Lock()
+		for j, n := range s.counts {
+			m2[j] += n
+		}
+		s.mu.Unlock()
+	}
+	return &m2
+}
+
+// Reset sets all counts in m to zero.
+func (m *ShardedInt) Reset() {
+	shards := m.init()
+	for i := range shards {
+		s := &shards[i]
+		s.mu.Lock()
+		s.counts = Int{}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,70 @@
+package bytemap_test
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+type concurrentInt interface {
+	io.Writer
+	io.StringWriter
+	Snapshot() *bytemap.Int
+	Reset()
+}
+
+func testConcurrent(t *testing.T, m concurrentInt) {
+	short := "hello, world"
+	long := strings.Repeat("abcdefghij", 100)
+	const workers, writes = 8, 100
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range writes {
+				if (i+j)%2 == 0 {
+					m.WriteString(short)
+				} else {
+					m.Write([]byte(long))
+				}
+				// Snapshot concurrently with writes
+				if j%10 == 0 {
+					m.Snapshot()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	var want bytemap.Int
+	for range workers * writes / 2 {
+		want.WriteString(short)
+		want.WriteString(long)
+	}
+	if got := m.Snapshot(); !got.Equals(&want) {
+		t.Fatalf("got %v; want %v", got.ToMap(), want.ToMap())
+	}
+	m.Reset()
+	if got := m.Snapshot(); !got.Equals(&bytemap.Int{}) {
+		t.Fatalf("Reset left %v", got.ToMap())
+	}
+}
+
+func TestAtomicInt(t *testing.T) {
+	var m bytemap.AtomicInt
+	testConcurrent(t, &m)
+	m.Add('a', 3)
+	m.Add('a', -1)
+	if m.Get('a') != 2 || m.Snapshot().Get('a') != 2 {
+		t.Fatal(m.Get('a'))
+	}
+}
+
+func TestShardedInt(t *testing.T) {
+	testConcurrent(t, bytemap.NewShardedInt())
+	var m bytemap.ShardedInt
+	testConcurrent(t, &m)
+}
@@ -0,0 +1,71 @@
+package bytemap
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// Merge returns a new Int with the sum of the counts in ms.
+func Merge(ms ...*Int) *Int {
+	var m2 Int
+	for _, m := range ms {
+		m2.AddInPlace(m)
+	}
+	return &m2
+}
+
+// CountReaderAt counts the first size bytes of r
+// by splitting them into chunks counted concurrently by workers goroutines.
+// If workers is less than 1, it uses runtime.GOMAXPROCS workers.
+// If r ends before size bytes, only the bytes read are counted.
+// If reading fails, it returns nil and the first error.
+func CountReaderAt(r io.ReaderAt, size int64, workers int) (*Int, error) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	// Don't bother splitting small inputs into tiny chunks
+	const minChunk = 1 << 16
+	workers = int(max(min(int64(workers), size/minChunk), 1))
+	counts := make([]*Int, workers)
+	errs := make([]error, workers)
+	chunk := size / int64(workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		off := int64(i) * chunk
+		n := chunk
+		if i == workers-1 {
+			n = size - off
+		}
+		counts[i] = new(Int)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, 32*1024)
+			_, errs[i] = io.CopyBuffer(counts[i], io.NewSectionReader(r, off, n), buf)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return Merge(counts...), nil
+}
+
+// CountFile counts the bytes of the file at path
+// using CountReaderAt with workers goroutines.
+func CountFile(path string, workers int) (*Int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return CountReaderAt(f, info.Size(), workers)
+}
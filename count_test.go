@@ -0,0 +1,78 @@
+package bytemap_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestMerge(t *testing.T) {
+	var a, b, want bytemap.Int
+	a.WriteString("hello")
+	b.WriteString("world")
+	want.WriteString("helloworld")
+	if got := bytemap.Merge(&a, &b); !got.Equals(&want) {
+		t.Fatal(got.ToMap())
+	}
+	if got := bytemap.Merge(); !got.Equals(&bytemap.Int{}) {
+		t.Fatal(got.ToMap())
+	}
+}
+
+func TestCountFile(t *testing.T) {
+	data, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want bytemap.Int
+	want.Write(data)
+	for _, workers := range []int{-1, 0, 1, 3, 8, 1000} {
+		got, err := bytemap.CountFile("testdata/moby-dick.txt", workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equals(&want) {
+			t.Errorf("workers=%d: counts differ", workers)
+		}
+	}
+	if _, err := bytemap.CountFile("testdata/missing.txt", 1); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestCountReaderAt(t *testing.T) {
+	s := strings.Repeat("abc", 100_000)
+	var want bytemap.Int
+	want.WriteString(s)
+	got, err := bytemap.CountReaderAt(strings.NewReader(s), int64(len(s)), 4)
+	if err != nil || !got.Equals(&want) {
+		t.Fatal(err, got.ToMap())
+	}
+	// Size past the end counts only what is there
+	got, err = bytemap.CountReaderAt(strings.NewReader(s), int64(len(s))+100, 4)
+	if err != nil || !got.Equals(&want) {
+		t.Fatal(err, got.ToMap())
+	}
+	got, err = bytemap.CountReaderAt(bytes.NewReader(nil), 0, 4)
+	if err != nil || !got.Equals(&bytemap.Int{}) {
+		t.Fatal(err, got)
+	}
+	errBoom := errors.New("boom")
+	_, err = bytemap.CountReaderAt(errReaderAt{errBoom}, 1<<20, 4)
+	if !errors.Is(err, errBoom) {
+		t.Fatal(err)
+	}
+}
+
+type errReaderAt struct{ err error }
+
+func (r errReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	return 0, r.err
+}
+
+var _ io.ReaderAt = errReaderAt{}
@@ -0,0 +1,276 @@
+package bytemap
+
+import (
+	"math"
+)
+
+func chiSquared[V int | float64](observed, expected *[Len]V) (stat, pValue float64) {
+	var n float64
+	for _, v := range observed {
+		if v > 0 {
+			n += float64(v)
+		}
+	}
+	q, ok := probabilities(expected)
+	if n == 0 || !ok {
+		return math.NaN(), math.NaN()
+	}
+	df := -1
+	for i, qi := range q {
+		o := float64(max(observed[i], 0))
+		if qi == 0 {
+			if o > 0 {
+				return math.Inf(1), 0
+			}
+			continue
+		}
+		df++
+		e := n * qi
+		stat += (o - e) * (o - e) / e
+	}
+	if df < 1 {
+		return stat, math.NaN()
+	}
+	return stat, gammaQ(float64(df)/2, stat/2)
+}
+
+func kolmogorovSmirnov[V int | float64](m, other *[Len]V) (d, pValue float64) {
+	var n1, n2 float64
+	for i := range m {
+		n1 += float64(max(m[i], 0))
+		n2 += float64(max(other[i], 0))
+	}
+	if n1 == 0 || n2 == 0 {
+		return math.NaN(), math.NaN()
+	}
+	var cdf1, cdf2 float64
+	for i := range m {
+		cdf1 += float64(max(m[i], 0)) / n1
+		cdf2 += float64(max(other[i], 0)) / n2
+		d = max(d, math.Abs(cdf1-cdf2))
+	}
+	ne := math.Sqrt(n1 * n2 / (n1 + n2))
+	return d, kolmogorovQ((ne + 0.12 + 0.11/ne) * d)
+}
+
+func cosineSimilarity(p, q *[Len]float64) float64 {
+	var dot, pp, qq float64
+	for i := range p {
+		dot += p[i] * q[i]
+		pp += p[i] * p[i]
+		qq += q[i] * q[i]
+	}
+	return dot / math.Sqrt(pp*qq)
+}
+
+func l1Distance(p, q *[Len]float64) float64 {
+	var d float64
+	for i := range p {
+		d += math.Abs(p[i] - q[i])
+	}
+	return d
+}
+
+func l2Distance(p, q *[Len]float64) float64 {
+	var d float64
+	for i := range p {
+		d += (p[i] - q[i]) * (p[i] - q[i])
+	}
+	return math.Sqrt(d)
+}
+
+func hellingerDistance(p, q *[Len]float64) float64 {
+	var bc float64
+	for i := range p {
+		bc += math.Sqrt(p[i] * q[i])
+	}
+	return math.Sqrt(max(1-bc, 0))
+}
+
+func totalVariation(p, q *[Len]float64) float64 {
+	return l1Distance(p, q) / 2
+}
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a, x).
+func gammaQ(a, x float64) float64 {
+	switch {
+	case math.IsInf(x, 1):
+		return 0
+	case x <= 0:
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(a*math.Log(x) - x - lgamma)
+	if x < a+1 {
+		// Series for P(a, x)
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return max(1-sum*prefix, 0)
+	}
+	// Continued fraction for Q(a, x) by the modified Lentz method
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return min(prefix*h, 1)
+}
+
+// kolmogorovQ returns the complementary cumulative distribution function
+// of the Kolmogorov distribution.
+func kolmogorovQ(lambda float64) float64 {
+	if lambda < 0.2 {
+		return 1
+	}
+	var sum float64
+	sign := 1.0
+	for j := 1; j <= 100; j++ {
+		term := sign * math.Exp(-2*float64(j*j)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-16 {
+			break
+		}
+		sign = -sign
+	}
+	return min(max(2*sum, 0), 1)
+}
+
+// ChiSquared performs Pearson's chi-squared goodness of fit test
+// of the counts in m against the distribution of expected,
+// which is scaled to the same total as m.
+// It returns the test statistic and its p-value.
+// A small p-value means m is unlikely to be a sample from expected.
+// Bytes with zero or negative counts are ignored.
+//
+// If m has a byte which expected lacks, the statistic is +Inf.
+// If either has no positive counts, it returns NaN, NaN.
+func (m *Int) ChiSquared(expected *Int) (stat, pValue float64) {
+	return chiSquared((*[Len]int)(m), (*[Len]int)(expected))
+}
+
+// KolmogorovSmirnov performs a two-sample Kolmogorov-Smirnov test
+// of m and other, treating bytes as ordered values.
+// It returns the largest difference between their cumulative distributions
+// and its asymptotic p-value,
+// which is conservative because bytes are discrete.
+// If either has no positive counts, it returns NaN, NaN.
+func (m *Int) KolmogorovSmirnov(other *Int) (d, pValue float64) {
+	return kolmogorovSmirnov((*[Len]int)(m), (*[Len]int)(other))
+}
+
+// CosineSimilarity returns the cosine of the angle between m and other,
+// from 0 for no bytes in common to 1 for the same distribution.
+// If either has no positive counts, it returns NaN.
+func (m *Int) CosineSimilarity(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), cosineSimilarity)
+}
+
+// L1Distance returns the sum of the absolute differences
+// between the distributions of m and other, from 0 to 2.
+// If either has no positive counts, it returns NaN.
+func (m *Int) L1Distance(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), l1Distance)
+}
+
+// L2Distance returns the Euclidean distance
+// between the distributions of m and other, from 0 to √2.
+// If either has no positive counts, it returns NaN.
+func (m *Int) L2Distance(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), l2Distance)
+}
+
+// HellingerDistance returns the Hellinger distance
+// between the distributions of m and other, from 0 to 1.
+// If either has no positive counts, it returns NaN.
+func (m *Int) HellingerDistance(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), hellingerDistance)
+}
+
+// TotalVariation returns the total variation distance
+// between the distributions of m and other, from 0 to 1.
+// If either has no positive counts, it returns NaN.
+func (m *Int) TotalVariation(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), totalVariation)
+}
+
+// ChiSquared performs Pearson's chi-squared goodness of fit test
+// of the values in m as counts against the distribution of expected,
+// which is scaled to the same total as m.
+// It returns the test statistic and its p-value.
+// A small p-value means m is unlikely to be a sample from expected.
+// Bytes with zero or negative values are ignored.
+//
+// If m has a byte which expected lacks, the statistic is +Inf.
+// If either has no positive values, it returns NaN, NaN.
+func (m *Float) ChiSquared(expected *Float) (stat, pValue float64) {
+	return chiSquared((*[Len]float64)(m), (*[Len]float64)(expected))
+}
+
+// KolmogorovSmirnov performs a two-sample Kolmogorov-Smirnov test
+// of the values in m and other as counts, treating bytes as ordered values.
+// It returns the largest difference between their cumulative distributions
+// and its asymptotic p-value,
+// which is conservative because bytes are discrete.
+// If either has no positive values, it returns NaN, NaN.
+func (m *Float) KolmogorovSmirnov(other *Float) (d, pValue float64) {
+	return kolmogorovSmirnov((*[Len]float64)(m), (*[Len]float64)(other))
+}
+
+// CosineSimilarity returns the cosine of the angle between m and other,
+// from 0 for no bytes in common to 1 for the same distribution.
+// If either has no positive values, it returns NaN.
+func (m *Float) CosineSimilarity(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), cosineSimilarity)
+}
+
+// L1Distance returns the sum of the absolute differences
+// between the distributions of m and other, from 0 to 2.
+// If either has no positive values, it returns NaN.
+func (m *Float) L1Distance(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), l1Distance)
+}
+
+// L2Distance returns the Euclidean distance
+// between the distributions of m and other, from 0 to √2.
+// If either has no positive values, it returns NaN.
+func (m *Float) L2Distance(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), l2Distance)
+}
+
+// HellingerDistance returns the Hellinger distance
+// between the distributions of m and other, from 0 to 1.
+// If either has no positive values, it returns NaN.
+func (m *Float) HellingerDistance(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), hellingerDistance)
+}
+
+// TotalVariation returns the total variation distance
+// between the distributions of m and other, from 0 to 1.
+// If either has no positive values, it returns NaN.
+func (m *Float) TotalVariation(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), totalVariation)
+}
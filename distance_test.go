@@ -0,0 +1,174 @@
+package bytemap_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestChiSquared(t *testing.T) {
+	var uniform3, uniform2 bytemap.Int
+	uniform3.WriteString("abc")
+	uniform2.WriteString("ab")
+	for _, tc := range []struct {
+		a, b, c  int
+		expected *bytemap.Int
+		stat, p  float64
+	}{
+		// With 2 degrees of freedom, p = exp(-stat/2)
+		{10, 20, 30, &uniform3, 10, math.Exp(-5)},
+		{20, 20, 20, &uniform3, 0, 1},
+		// With 1 degree of freedom, p = erfc(sqrt(stat/2))
+		{60, 40, 0, &uniform2, 4, math.Erfc(math.Sqrt(2))},
+		{52, 48, 0, &uniform2, 0.16, math.Erfc(math.Sqrt(0.08))},
+		{1, 0, 1, &uniform2, math.Inf(1), 0},
+	} {
+		var m bytemap.Int
+		m['a'], m['b'], m['c'] = tc.a, tc.b, tc.c
+		stat, p := m.ChiSquared(tc.expected)
+		if !approxEqual(stat, tc.stat) && stat != tc.stat || !approxEqual(p, tc.p) {
+			t.Errorf("ChiSquared(%d, %d, %d) = %v, %v; want %v, %v",
+				tc.a, tc.b, tc.c, stat, p, tc.stat, tc.p)
+		}
+		fstat, fp := m.ToFloat().ChiSquared(tc.expected.ToFloat())
+		if fstat != stat || fp != p {
+			t.Errorf("Float.ChiSquared = %v, %v; want %v, %v", fstat, fp, stat, p)
+		}
+	}
+	// Scaling the expected distribution makes no difference
+	var scaled bytemap.Int
+	scaled['a'], scaled['b'], scaled['c'] = 7, 7, 7
+	var m bytemap.Int
+	m['a'], m['b'], m['c'] = 10, 20, 30
+	if stat, p := m.ChiSquared(&scaled); !approxEqual(stat, 10) || !approxEqual(p, math.Exp(-5)) {
+		t.Error(stat, p)
+	}
+	var empty bytemap.Int
+	if stat, p := empty.ChiSquared(&uniform3); !math.IsNaN(stat) || !math.IsNaN(p) {
+		t.Error(stat, p)
+	}
+	if stat, p := m.ChiSquared(&empty); !math.IsNaN(stat) || !math.IsNaN(p) {
+		t.Error(stat, p)
+	}
+}
+
+func TestChiSquaredLargeDF(t *testing.T) {
+	// The 95th percentile of the chi-squared distribution
+	// with 255 degrees of freedom is about 293.25.
+	var expected bytemap.Float
+	for i := range bytemap.Len {
+		expected[i] = 1
+	}
+	// Spread a statistic of 293.25 across the bins
+	// by moving counts away from a uniform 1000 per byte.
+	var observed bytemap.Float
+	delta := math.Sqrt(293.2478 * 1000 / 256)
+	for i := range bytemap.Len {
+		if i%2 == 0 {
+			observed[i] = 1000 + delta
+		} else {
+			observed[i] = 1000 - delta
+		}
+	}
+	stat, p := observed.ChiSquared(&expected)
+	if math.Abs(stat-293.2478) > 1e-6 || math.Abs(p-0.05) > 1e-4 {
+		t.Fatal(stat, p)
+	}
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	var a, b, ab bytemap.Int
+	a['a'] = 100
+	b['b'] = 100
+	ab['a'], ab['b'] = 50, 50
+	for _, tc := range []struct {
+		name string
+		m, o *bytemap.Int
+		d    float64
+		maxP float64
+		minP float64
+	}{
+		{"same", &ab, &ab, 0, 1, 1},
+		{"disjoint", &a, &b, 1, 1e-9, 0},
+		{"half", &a, &ab, 0.5, 1e-4, 0},
+	} {
+		d, p := tc.m.KolmogorovSmirnov(tc.o)
+		if !approxEqual(d, tc.d) || p > tc.maxP || p < tc.minP {
+			t.Errorf("%s: got %v, %v", tc.name, d, p)
+		}
+		fd, fp := tc.m.ToFloat().KolmogorovSmirnov(tc.o.ToFloat())
+		if fd != d || fp != p {
+			t.Errorf("%s: Float got %v, %v", tc.name, fd, fp)
+		}
+	}
+	// The critical value for alpha = 0.05 is about 1.358
+	var x, y bytemap.Int
+	x['a'], x['b'] = 5000, 5000
+	n := 10000.0
+	ne := math.Sqrt(n * n / (2 * n))
+	d := 1.358 / (ne + 0.12 + 0.11/ne)
+	y['a'] = int(math.Round((0.5 + d) * n))
+	y['b'] = int(n) - y['a']
+	if _, p := x.KolmogorovSmirnov(&y); math.Abs(p-0.05) > 0.005 {
+		t.Fatal(p)
+	}
+}
+
+func TestDistances(t *testing.T) {
+	var p, q, r bytemap.Float
+	p['a'], p['b'] = 0.5, 0.5
+	q['a'], q['b'] = 0.75, 0.25
+	r['c'] = 2
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"CosineSimilarity", p.CosineSimilarity(&q),
+			(0.5*0.75 + 0.5*0.25) / math.Sqrt(0.5*(0.75*0.75+0.25*0.25))},
+		{"CosineSimilarity self", q.CosineSimilarity(&q), 1},
+		{"CosineSimilarity disjoint", q.CosineSimilarity(&r), 0},
+		{"L1Distance", p.L1Distance(&q), 0.5},
+		{"L1Distance disjoint", p.L1Distance(&r), 2},
+		{"L2Distance", p.L2Distance(&q), math.Sqrt(2 * 0.25 * 0.25)},
+		{"L2Distance disjoint", p.L2Distance(&r), math.Sqrt(1.5)},
+		{"HellingerDistance", p.HellingerDistance(&q),
+			math.Sqrt(1 - math.Sqrt(0.5*0.75) - math.Sqrt(0.5*0.25))},
+		{"HellingerDistance self", p.HellingerDistance(&p), 0},
+		{"HellingerDistance disjoint", p.HellingerDistance(&r), 1},
+		{"TotalVariation", p.TotalVariation(&q), 0.25},
+		{"TotalVariation disjoint", q.TotalVariation(&r), 1},
+	} {
+		if !approxEqual(tc.got, tc.want) {
+			t.Errorf("%s = %v; want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	var pi, qi, empty bytemap.Int
+	pi.WriteString("ab")
+	qi.WriteString("aaab")
+	for _, tc := range []struct {
+		name string
+		i, f float64
+	}{
+		{"CosineSimilarity", pi.CosineSimilarity(&qi), p.CosineSimilarity(&q)},
+		{"L1Distance", pi.L1Distance(&qi), p.L1Distance(&q)},
+		{"L2Distance", pi.L2Distance(&qi), p.L2Distance(&q)},
+		{"HellingerDistance", pi.HellingerDistance(&qi), p.HellingerDistance(&q)},
+		{"TotalVariation", pi.TotalVariation(&qi), p.TotalVariation(&q)},
+	} {
+		if !approxEqual(tc.i, tc.f) {
+			t.Errorf("%s: Int %v != Float %v", tc.name, tc.i, tc.f)
+		}
+	}
+	for _, got := range []float64{
+		pi.CosineSimilarity(&empty),
+		empty.L1Distance(&pi),
+		pi.L2Distance(&empty),
+		empty.HellingerDistance(&empty),
+		pi.TotalVariation(&empty),
+	} {
+		if !math.IsNaN(got) {
+			t.Error(got)
+		}
+	}
+}
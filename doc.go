@@ -1,5 +1,5 @@
 // Package bytemap contains types for making maps
-// from bytes to bool, integer, or float.
+// from bytes to bool, integer, float, or any other type.
 // The maps are backed by arrays of 256 entries.
 package bytemap
 
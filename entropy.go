@@ -0,0 +1,209 @@
+package bytemap
+
+import (
+	"math"
+)
+
+// probabilities normalizes the positive values of m so that they sum to 1.
+// If m has no positive values, ok is false.
+func probabilities[V int | float64](m *[Len]V) (p [Len]float64, ok bool) {
+	var total float64
+	for _, v := range m {
+		if v > 0 {
+			total += float64(v)
+		}
+	}
+	if total == 0 || math.IsInf(total, 0) {
+		return p, false
+	}
+	for i, v := range m {
+		if v > 0 {
+			p[i] = float64(v) / total
+		}
+	}
+	return p, true
+}
+
+func entropy(p *[Len]float64) float64 {
+	var h float64
+	for _, pi := range p {
+		if pi > 0 {
+			h -= pi * math.Log2(pi)
+		}
+	}
+	return h
+}
+
+func minEntropy(p *[Len]float64) float64 {
+	var most float64
+	for _, pi := range p {
+		most = max(most, pi)
+	}
+	return -math.Log2(most)
+}
+
+func renyi(p *[Len]float64, alpha float64) float64 {
+	switch {
+	case alpha < 0 || math.IsNaN(alpha):
+		return math.NaN()
+	case alpha == 1:
+		return entropy(p)
+	case math.IsInf(alpha, 1):
+		return minEntropy(p)
+	}
+	var sum float64
+	for _, pi := range p {
+		if pi > 0 {
+			sum += math.Pow(pi, alpha)
+		}
+	}
+	return math.Log2(sum) / (1 - alpha)
+}
+
+func klDivergence(p, q *[Len]float64) float64 {
+	var d float64
+	for i, pi := range p {
+		if pi > 0 {
+			d += pi * math.Log2(pi/q[i])
+		}
+	}
+	return max(d, 0)
+}
+
+func crossEntropy(p, q *[Len]float64) float64 {
+	var h float64
+	for i, pi := range p {
+		if pi > 0 {
+			h -= pi * math.Log2(q[i])
+		}
+	}
+	return h
+}
+
+func jensenShannon(p, q *[Len]float64) float64 {
+	var mid [Len]float64
+	for i := range mid {
+		mid[i] = (p[i] + q[i]) / 2
+	}
+	return min(max((klDivergence(p, &mid)+klDivergence(q, &mid))/2, 0), 1)
+}
+
+// compare normalizes m and other and applies f to them.
+// If either has no positive values, it returns NaN.
+func compare[V int | float64](m, other *[Len]V, f func(p, q *[Len]float64) float64) float64 {
+	p, ok := probabilities(m)
+	if !ok {
+		return math.NaN()
+	}
+	q, ok := probabilities(other)
+	if !ok {
+		return math.NaN()
+	}
+	return f(&p, &q)
+}
+
+// Entropy returns the Shannon entropy of m in bits per byte,
+// treating the counts in m as a distribution of bytes.
+// Bytes with zero or negative counts are ignored.
+// If m has no positive counts, it returns 0.
+func (m *Int) Entropy() float64 {
+	p, _ := probabilities((*[Len]int)(m))
+	return entropy(&p)
+}
+
+// MinEntropy returns the min-entropy of m in bits per byte,
+// which is determined by the most common byte.
+// If m has no positive counts, it returns +Inf.
+func (m *Int) MinEntropy() float64 {
+	p, _ := probabilities((*[Len]int)(m))
+	return minEntropy(&p)
+}
+
+// Renyi returns the Rényi entropy of order alpha of m in bits per byte.
+// An alpha of 1 gives the Shannon entropy,
+// and an alpha of +Inf gives the min-entropy.
+// If alpha is negative or NaN, it returns NaN.
+// If m has no positive counts, it returns NaN.
+func (m *Int) Renyi(alpha float64) float64 {
+	p, ok := probabilities((*[Len]int)(m))
+	if !ok {
+		return math.NaN()
+	}
+	return renyi(&p, alpha)
+}
+
+// KLDivergence returns the Kullback-Leibler divergence
+// of m from other in bits.
+// If m has a byte which other lacks, it returns +Inf.
+// If either has no positive counts, it returns NaN.
+func (m *Int) KLDivergence(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), klDivergence)
+}
+
+// CrossEntropy returns the cross-entropy of other relative to m in bits.
+// If m has a byte which other lacks, it returns +Inf.
+// If either has no positive counts, it returns NaN.
+func (m *Int) CrossEntropy(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), crossEntropy)
+}
+
+// JensenShannon returns the Jensen-Shannon divergence
+// between m and other in bits, from 0 to 1.
+// Unlike KLDivergence, it is symmetric and always finite.
+// If either has no positive counts, it returns NaN.
+func (m *Int) JensenShannon(other *Int) float64 {
+	return compare((*[Len]int)(m), (*[Len]int)(other), jensenShannon)
+}
+
+// Entropy returns the Shannon entropy of m in bits per byte,
+// treating the values in m as a distribution of bytes.
+// Bytes with zero or negative values are ignored.
+// If m has no positive values, it returns 0.
+func (m *Float) Entropy() float64 {
+	p, _ := probabilities((*[Len]float64)(m))
+	return entropy(&p)
+}
+
+// MinEntropy returns the min-entropy of m in bits per byte,
+// which is determined by the byte with the largest value.
+// If m has no positive values, it returns +Inf.
+func (m *Float) MinEntropy() float64 {
+	p, _ := probabilities((*[Len]float64)(m))
+	return minEntropy(&p)
+}
+
+// Renyi returns the Rényi entropy of order alpha of m in bits per byte.
+// An alpha of 1 gives the Shannon entropy,
+// and an alpha of +Inf gives the min-entropy.
+// If alpha is negative or NaN, it returns NaN.
+// If m has no positive values, it returns NaN.
+func (m *Float) Renyi(alpha float64) float64 {
+	p, ok := probabilities((*[Len]float64)(m))
+	if !ok {
+		return math.NaN()
+	}
+	return renyi(&p, alpha)
+}
+
+// KLDivergence returns the Kullback-Leibler divergence
+// of m from other in bits.
+// If m has a byte which other lacks, it returns +Inf.
+// If either has no positive values, it returns NaN.
+func (m *Float) KLDivergence(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), klDivergence)
+}
+
+// CrossEntropy returns the cross-entropy of other relative to m in bits.
+// If m has a byte which other lacks, it returns +Inf.
+// If either has no positive values, it returns NaN.
+func (m *Float) CrossEntropy(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), crossEntropy)
+}
+
+// JensenShannon returns the Jensen-Shannon divergence
+// between m and other in bits, from 0 to 1.
+// Unlike KLDivergence, it is symmetric and always finite.
+// If either has no positive values, it returns NaN.
+func (m *Float) JensenShannon(other *Float) float64 {
+	return compare((*[Len]float64)(m), (*[Len]float64)(other), jensenShannon)
+}
@@ -0,0 +1,121 @@
+package bytemap_test
+
+import (
+	"io"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEntropyMobyDick(t *testing.T) {
+	f, err := os.Open("testdata/moby-dick.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var m bytemap.Int
+	if _, err = io.Copy(&m, f); err != nil {
+		t.Fatal(err)
+	}
+	mf := m.ToFloat()
+	// Computed independently from the raw byte counts
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Entropy", m.Entropy(), 4.598597305540366},
+		{"Float.Entropy", mf.Entropy(), 4.598597305540366},
+		{"MinEntropy", m.MinEntropy(), 2.690770017647349},
+		{"Float.MinEntropy", mf.MinEntropy(), 2.690770017647349},
+		{"Renyi(2)", m.Renyi(2), 4.031253076069957},
+		{"Float.Renyi(2)", mf.Renyi(2), 4.031253076069957},
+		{"Renyi(1)", m.Renyi(1), 4.598597305540366},
+		{"Renyi(Inf)", m.Renyi(math.Inf(1)), 2.690770017647349},
+		{"Renyi(0)", m.Renyi(0), math.Log2(float64(m.Nonzero()))},
+		{"KLDivergence(self)", m.KLDivergence(&m), 0},
+		{"JensenShannon(self)", mf.JensenShannon(mf), 0},
+		{"CrossEntropy(self)", m.CrossEntropy(&m), 4.598597305540366},
+	} {
+		if !approxEqual(tc.got, tc.want) {
+			t.Errorf("%s = %v; want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	// Shannon entropy is an upper bound on min-entropy
+	// and Rényi entropy decreases with alpha
+	prev := math.Inf(1)
+	for _, alpha := range []float64{0, 0.5, 1, 2, 10, math.Inf(1)} {
+		h := m.Renyi(alpha)
+		if h > prev+1e-9 {
+			t.Errorf("Renyi(%v) = %v > %v", alpha, h, prev)
+		}
+		prev = h
+	}
+}
+
+func TestDivergence(t *testing.T) {
+	var p, q, r bytemap.Float
+	p['a'], p['b'] = 0.5, 0.5
+	q['a'], q['b'] = 0.75, 0.25
+	r['c'] = 1
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Entropy", p.Entropy(), 1},
+		{"MinEntropy", q.MinEntropy(), -math.Log2(0.75)},
+		{"KLDivergence", p.KLDivergence(&q),
+			0.5*math.Log2(0.5/0.75) + 0.5*math.Log2(0.5/0.25)},
+		{"KLDivergence reversed", q.KLDivergence(&p),
+			0.75*math.Log2(0.75/0.5) + 0.25*math.Log2(0.25/0.5)},
+		{"CrossEntropy", p.CrossEntropy(&q),
+			-0.5*math.Log2(0.75) - 0.5*math.Log2(0.25)},
+		{"KLDivergence disjoint", p.KLDivergence(&r), math.Inf(1)},
+		{"CrossEntropy disjoint", p.CrossEntropy(&r), math.Inf(1)},
+		{"JensenShannon disjoint", p.JensenShannon(&r), 1},
+		{"JensenShannon symmetric", p.JensenShannon(&q), q.JensenShannon(&p)},
+	} {
+		if tc.got != tc.want && !approxEqual(tc.got, tc.want) {
+			t.Errorf("%s = %v; want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	// Scaling counts does not change anything
+	var pi, qi bytemap.Int
+	pi.WriteString("ab")
+	qi.WriteString("aaab")
+	if !approxEqual(pi.KLDivergence(&qi), p.KLDivergence(&q)) ||
+		!approxEqual(pi.JensenShannon(&qi), p.JensenShannon(&q)) ||
+		!approxEqual(pi.CrossEntropy(&qi), p.CrossEntropy(&q)) {
+		t.Fatal("Int and Float disagree")
+	}
+}
+
+func TestEntropyEmpty(t *testing.T) {
+	var m, full bytemap.Int
+	full.WriteString("abc")
+	m['x'] = -5
+	if h := m.Entropy(); h != 0 {
+		t.Error(h)
+	}
+	if h := m.MinEntropy(); !math.IsInf(h, 1) {
+		t.Error(h)
+	}
+	for _, got := range []float64{
+		m.Renyi(2),
+		full.Renyi(-1),
+		full.Renyi(math.NaN()),
+		m.KLDivergence(&full),
+		full.KLDivergence(&m),
+		m.CrossEntropy(&full),
+		full.JensenShannon(&m),
+	} {
+		if !math.IsNaN(got) {
+			t.Error(got)
+		}
+	}
+}
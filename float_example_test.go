@@ -40,3 +40,18 @@ func ExampleFloat_SetFrequencies() {
 	// 'd': 03.0%
 	// 'u': 02.1%
 }
+
+func ExampleFloat_Entropy() {
+	var text, random bytemap.Float
+	text.WriteString("the quick brown fox jumps over the lazy dog")
+	for i := range bytemap.Len {
+		random[i] = 1
+	}
+	fmt.Printf("text:   %.2f bits per byte\n", text.Entropy())
+	fmt.Printf("random: %.2f bits per byte\n", random.Entropy())
+	fmt.Printf("divergence: %.2f\n", text.JensenShannon(&random))
+	// Output:
+	// text:   4.39 bits per byte
+	// random: 8.00 bits per byte
+	// divergence: 0.76
+}
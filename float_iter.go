@@ -26,3 +26,36 @@ func (m *Float) MostCommon() iter.Seq2[byte, float64] {
 		}
 	}
 }
+
+// All returns a sequence of every byte and its value in m, in byte order.
+func (m *Float) All() iter.Seq2[byte, float64] {
+	return func(yield func(byte, float64) bool) {
+		for i, v := range m {
+			if !yield(byte(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the bytes with non-zero values in m, in byte order.
+func (m *Float) Keys() iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		for i, v := range m {
+			if v != 0 && !yield(byte(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the value for every byte in m, in byte order.
+func (m *Float) Values() iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
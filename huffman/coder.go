@@ -0,0 +1,173 @@
+package huffman
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+// ErrCorrupt is returned by a Decoder
+// when its input contains a bit sequence which is not a code.
+var ErrCorrupt = errors.New("invalid code in input")
+
+var errClosed = errors.New("write to closed Encoder")
+
+// Encoder writes the codes for bytes written to it
+// as a stream of bits, most significant bit first.
+type Encoder struct {
+	w     io.Writer
+	code  *Code
+	acc   uint64
+	nbits uint
+	buf   []byte
+	off   int64
+	err   error
+}
+
+// NewEncoder returns an Encoder which writes the codes from code to w.
+// The caller must call Close to write the final partial byte.
+func NewEncoder(w io.Writer, code *Code) *Encoder {
+	return &Encoder{w: w, code: code, buf: make([]byte, 0, 4096)}
+}
+
+var _ io.WriteCloser = (*Encoder)(nil)
+
+// Write satisfies io.Writer.
+// If p contains a byte with no code,
+// it encodes the bytes before it and returns a *bytemap.InvalidByteError.
+func (e *Encoder) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	for i, c := range p {
+		n := uint(e.code.lengths[c])
+		if n == 0 {
+			if err := e.flush(); err != nil {
+				return i, err
+			}
+			return i, &bytemap.InvalidByteError{Byte: c, Offset: e.off}
+		}
+		// Bits above nbits are stale and get shifted out
+		e.acc = e.acc<<n | uint64(e.code.codes[c])
+		e.nbits += n
+		for e.nbits >= 8 {
+			e.nbits -= 8
+			e.buf = append(e.buf, byte(e.acc>>e.nbits))
+		}
+		e.off++
+		if len(e.buf) == cap(e.buf) {
+			if err := e.flush(); err != nil {
+				return i + 1, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+func (e *Encoder) flush() error {
+	if len(e.buf) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(e.buf)
+	e.buf = e.buf[:0]
+	if err != nil {
+		e.err = err
+	}
+	return err
+}
+
+// Close writes any remaining bits, padded with zeros to a full byte.
+// It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.err != nil {
+		if e.err == errClosed {
+			return nil
+		}
+		return e.err
+	}
+	if e.nbits > 0 {
+		e.buf = append(e.buf, byte(e.acc<<(8-e.nbits)))
+		e.nbits = 0
+	}
+	if err := e.flush(); err != nil {
+		return err
+	}
+	e.err = errClosed
+	return nil
+}
+
+// Decoder reads the bytes for a stream of codes written by an Encoder.
+type Decoder struct {
+	r     io.ByteReader
+	code  *Code
+	n     int64
+	cur   byte
+	nbits uint
+	err   error
+}
+
+// NewDecoder returns a Decoder which decodes n bytes
+// from the codes in r using code.
+// Because the final byte of an encoding may be padded,
+// the number of bytes encoded must be known.
+func NewDecoder(r io.Reader, code *Code, n int64) *Decoder {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Decoder{r: br, code: code, n: n}
+}
+
+var _ io.Reader = (*Decoder)(nil)
+
+// Read satisfies io.Reader.
+func (d *Decoder) Read(p []byte) (int, error) {
+	for i := range p {
+		if d.err != nil {
+			return i, d.err
+		}
+		if d.n <= 0 {
+			return i, io.EOF
+		}
+		c, err := d.decode()
+		if err != nil {
+			d.err = err
+			return i, err
+		}
+		p[i] = c
+		d.n--
+	}
+	return len(p), nil
+}
+
+// decode reads one code a bit at a time.
+// Canonical codes of each length are consecutive,
+// so a code of length n is valid if it is less than
+// the first code of length n plus the number of codes of length n.
+func (d *Decoder) decode() (byte, error) {
+	code, first, index := 0, 0, 0
+	for n := 1; n <= d.code.maxLen; n++ {
+		if d.nbits == 0 {
+			c, err := d.r.ReadByte()
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			if err != nil {
+				return 0, err
+			}
+			d.cur, d.nbits = c, 8
+		}
+		d.nbits--
+		code |= int(d.cur>>d.nbits) & 1
+		count := int(d.code.count[n])
+		if code-first < count {
+			return d.code.symbols[index+code-first], nil
+		}
+		index += count
+		first = (first + count) << 1
+		code <<= 1
+	}
+	return 0, ErrCorrupt
+}
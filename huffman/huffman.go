@@ -0,0 +1,196 @@
+// Package huffman builds canonical, length-limited Huffman codes
+// from byte counts and encodes and decodes bit streams with them.
+package huffman
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+// MaxLength is the length in bits of the longest code BuildCode makes.
+const MaxLength = 15
+
+// ErrNoSymbols is returned by BuildCode
+// when no byte has a positive count.
+var ErrNoSymbols = errors.New("no bytes with positive counts")
+
+// Code is a canonical prefix code for bytes.
+// Codes are assigned in order of length and then byte value,
+// so a Code is fully described by its code lengths.
+type Code struct {
+	lengths [bytemap.Len]uint8
+	codes   [bytemap.Len]uint16
+	// count[n] is the number of codes of length n
+	count [MaxLength + 1]uint16
+	// maxLen is the length of the longest code
+	maxLen int
+	// symbols are the bytes with codes in canonical order
+	symbols []byte
+}
+
+// BuildCode returns an optimal prefix code for the positive counts in counts,
+// with no code longer than MaxLength bits.
+// Bytes with zero or negative counts get no code.
+// If only one byte has a positive count, its code is one bit long.
+func BuildCode(counts *bytemap.Int) (*Code, error) {
+	var leaves []leaf
+	for i, n := range counts {
+		if n > 0 {
+			leaves = append(leaves, leaf{byte(i), n})
+		}
+	}
+	var lengths bytemap.Int
+	switch len(leaves) {
+	case 0:
+		return nil, ErrNoSymbols
+	case 1:
+		lengths[leaves[0].c] = 1
+	default:
+		packageMerge(leaves, &lengths)
+	}
+	return FromLengths(&lengths)
+}
+
+type leaf struct {
+	c byte
+	n int
+}
+
+// item is a leaf or a package of two items from the previous level.
+type item struct {
+	weight int
+	// leaf is the index of the leaf, or -1 for a package
+	leaf        int
+	left, right int
+}
+
+// packageMerge sets lengths to the optimal code lengths for leaves
+// with none longer than MaxLength,
+// using the package-merge algorithm of Larmore and Hirschberg.
+func packageMerge(leaves []leaf, lengths *bytemap.Int) {
+	slices.SortStableFunc(leaves, func(a, b leaf) int {
+		return cmp.Compare(a.n, b.n)
+	})
+	levels := make([][]item, MaxLength)
+	for i, l := range leaves {
+		levels[0] = append(levels[0], item{l.n, i, 0, 0})
+	}
+	for d := 1; d < MaxLength; d++ {
+		prev := levels[d-1]
+		var packages []item
+		for i := 0; i+1 < len(prev); i += 2 {
+			packages = append(packages, item{prev[i].weight + prev[i+1].weight, -1, i, i + 1})
+		}
+		// Merge the leaves and packages, preferring leaves on ties
+		merged := make([]item, 0, len(leaves)+len(packages))
+		i := 0
+		for j, l := range leaves {
+			for i < len(packages) && packages[i].weight < l.n {
+				merged = append(merged, packages[i])
+				i++
+			}
+			merged = append(merged, levels[0][j])
+		}
+		levels[d] = append(merged, packages[i:]...)
+	}
+	// Each leaf's code length is the number of times it appears
+	// in the cheapest 2n-2 items of the last level.
+	var visit func(d, i int)
+	visit = func(d, i int) {
+		it := levels[d][i]
+		if it.leaf >= 0 {
+			lengths[leaves[it.leaf].c]++
+			return
+		}
+		visit(d-1, it.left)
+		visit(d-1, it.right)
+	}
+	for i := range 2*len(leaves) - 2 {
+		visit(MaxLength-1, i)
+	}
+}
+
+// FromLengths returns the canonical code with the given code lengths.
+// Bytes with a length of 0 get no code.
+// It returns an error if a length is negative or greater than MaxLength,
+// or if the lengths are too short to form a prefix code.
+// A code which does not use every bit pattern,
+// such as a single code of length 1, is allowed.
+func FromLengths(lengths *bytemap.Int) (*Code, error) {
+	var c Code
+	for i, n := range lengths {
+		if n < 0 || n > MaxLength {
+			return nil, fmt.Errorf("invalid code length %d for byte %q", n, byte(i))
+		}
+		c.lengths[i] = uint8(n)
+		if n > 0 {
+			c.count[n]++
+			c.maxLen = max(c.maxLen, n)
+		}
+	}
+	// Check the Kraft inequality
+	left := 1
+	for n := 1; n <= MaxLength; n++ {
+		left = left<<1 - int(c.count[n])
+		if left < 0 {
+			return nil, errors.New("invalid code lengths: too many short codes")
+		}
+	}
+	var next [MaxLength + 1]uint16
+	code := uint16(0)
+	for n := 1; n <= MaxLength; n++ {
+		code = (code + c.count[n-1]) << 1
+		next[n] = code
+	}
+	for n := 1; n <= MaxLength; n++ {
+		for i, l := range c.lengths {
+			if int(l) == n {
+				c.codes[i] = next[n]
+				next[n]++
+				c.symbols = append(c.symbols, byte(i))
+			}
+		}
+	}
+	return &c, nil
+}
+
+// Len returns the length in bits of the code for b,
+// or 0 if b has no code.
+func (c *Code) Len(b byte) int {
+	return int(c.lengths[b])
+}
+
+// Bits returns the code for b in the low Len(b) bits.
+func (c *Code) Bits(b byte) uint16 {
+	return c.codes[b]
+}
+
+// Lengths returns the length of the code for each byte.
+func (c *Code) Lengths() *bytemap.Int {
+	var m bytemap.Int
+	for i, n := range c.lengths {
+		m[i] = int(n)
+	}
+	return &m
+}
+
+// EncodedLen returns the number of bits needed to encode
+// bytes with the positive counts in counts.
+// If a byte with a positive count has no code, it returns -1.
+func (c *Code) EncodedLen(counts *bytemap.Int) int {
+	bits := 0
+	for i, n := range counts {
+		if n <= 0 {
+			continue
+		}
+		if c.lengths[i] == 0 {
+			return -1
+		}
+		bits += n * int(c.lengths[i])
+	}
+	return bits
+}
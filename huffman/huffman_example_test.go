@@ -0,0 +1,41 @@
+package huffman_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/earthboundkid/bytemap/v2"
+	"github.com/earthboundkid/bytemap/v2/huffman"
+)
+
+func Example() {
+	const text = "abracadabra"
+	var counts bytemap.Int
+	counts.WriteString(text)
+	code, err := huffman.BuildCode(&counts)
+	if err != nil {
+		panic(err)
+	}
+	for _, c := range []byte("abcdr") {
+		fmt.Printf("%q: %0*b\n", c, code.Len(c), code.Bits(c))
+	}
+
+	var buf bytes.Buffer
+	enc := huffman.NewEncoder(&buf, code)
+	_, _ = io.WriteString(enc, text)
+	_ = enc.Close()
+	fmt.Printf("%d bits in %d bytes\n", code.EncodedLen(&counts), buf.Len())
+
+	dec := huffman.NewDecoder(&buf, code, int64(len(text)))
+	decoded, _ := io.ReadAll(dec)
+	fmt.Println(string(decoded))
+	// Output:
+	// 'a': 0
+	// 'b': 100
+	// 'c': 101
+	// 'd': 110
+	// 'r': 111
+	// 23 bits in 3 bytes
+	// abracadabra
+}
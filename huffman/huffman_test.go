@@ -0,0 +1,231 @@
+package huffman_test
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"testing/iotest"
+
+	"github.com/earthboundkid/bytemap/v2"
+	"github.com/earthboundkid/bytemap/v2/huffman"
+)
+
+func roundTrip(t *testing.T, code *huffman.Code, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := huffman.NewEncoder(&buf, code)
+	if n, err := enc.Write(data); err != nil || n != len(data) {
+		t.Fatal(n, err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	encoded := bytes.Clone(buf.Bytes())
+	dec := huffman.NewDecoder(iotest.HalfReader(&buf), code, int64(len(data)))
+	got, err := io.ReadAll(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("round trip failed: got %d bytes; want %d", len(got), len(data))
+	}
+	return encoded
+}
+
+func TestMobyDick(t *testing.T) {
+	data, err := os.ReadFile("../testdata/moby-dick.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var counts bytemap.Int
+	counts.Write(data)
+	code, err := huffman.BuildCode(&counts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded := roundTrip(t, code, data)
+	bits := code.EncodedLen(&counts)
+	if len(encoded) != (bits+7)/8 {
+		t.Errorf("encoded %d bytes; EncodedLen says %d bits", len(encoded), bits)
+	}
+	// An optimal code is within one bit per byte of the entropy
+	n := float64(len(data))
+	if h := counts.Entropy(); float64(bits) < h*n || float64(bits) >= (h+1)*n {
+		t.Errorf("encoded %d bits; entropy bound %v", bits, h*n)
+	}
+	// The length limit binds, but costs little
+	if got, want := bits, huffmanCost(&counts); got < want || got > want+want/1000 {
+		t.Errorf("cost %d; unlimited Huffman cost %d", got, want)
+	}
+}
+
+// huffmanCost returns the cost in bits of an unlimited Huffman code,
+// which is the sum of the weights of the merged nodes.
+func huffmanCost(counts *bytemap.Int) int {
+	var h intHeap
+	for _, n := range counts {
+		if n > 0 {
+			h = append(h, n)
+		}
+	}
+	if len(h) == 1 {
+		return h[0]
+	}
+	heap.Init(&h)
+	cost := 0
+	for h.Len() > 1 {
+		a, b := heap.Pop(&h).(int), heap.Pop(&h).(int)
+		cost += a + b
+		heap.Push(&h, a+b)
+	}
+	return cost
+}
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func kraft(code *huffman.Code) float64 {
+	var sum float64
+	for _, n := range code.Lengths() {
+		if n > 0 {
+			sum += 1 / float64(int(1)<<n)
+		}
+	}
+	return sum
+}
+
+func TestLengthLimit(t *testing.T) {
+	// Fibonacci counts make an unlimited Huffman code as deep as possible
+	var counts bytemap.Int
+	a, b := 1, 1
+	for i := range 30 {
+		counts[i] = a
+		a, b = b, a+b
+	}
+	code, err := huffman.BuildCode(&counts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 30 {
+		if n := code.Len(byte(i)); n < 1 || n > huffman.MaxLength {
+			t.Errorf("Len(%d) = %d", i, n)
+		}
+	}
+	if k := kraft(code); k != 1 {
+		t.Errorf("Kraft sum %v", k)
+	}
+	if got, unlimited := code.EncodedLen(&counts), huffmanCost(&counts); got < unlimited {
+		t.Errorf("cost %d below unlimited cost %d", got, unlimited)
+	}
+	data := make([]byte, 0, 1000)
+	for i := range cap(data) {
+		data = append(data, byte(i%30))
+	}
+	roundTrip(t, code, data)
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		var counts bytemap.Int
+		for range 1 + r.IntN(256) {
+			counts[r.IntN(256)] = 1 + r.IntN(1<<r.IntN(20))
+		}
+		code, err := huffman.BuildCode(&counts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k := kraft(code); k != 1 && counts.Nonzero() > 1 {
+			t.Fatalf("Kraft sum %v", k)
+		}
+		if got, want := code.EncodedLen(&counts), huffmanCost(&counts); got < want {
+			t.Fatalf("cost %d below Huffman cost %d", got, want)
+		} else if code.Lengths().Stats().Max < huffman.MaxLength && got != want {
+			t.Fatalf("cost %d; want Huffman cost %d", got, want)
+		}
+		var data []byte
+		for i, n := range counts {
+			data = append(data, bytes.Repeat([]byte{byte(i)}, min(n, 10))...)
+		}
+		r.Shuffle(len(data), func(i, j int) { data[i], data[j] = data[j], data[i] })
+		roundTrip(t, code, data)
+	}
+}
+
+func TestSingleSymbol(t *testing.T) {
+	var counts bytemap.Int
+	counts['x'] = 10
+	code, err := huffman.BuildCode(&counts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.Len('x') != 1 || code.Len('y') != 0 {
+		t.Fatal(code.Lengths().ToMap())
+	}
+	encoded := roundTrip(t, code, []byte("xxxxxxxxx"))
+	if len(encoded) != 2 {
+		t.Fatalf("encoded %d bytes", len(encoded))
+	}
+	// A 1 bit is not a code
+	_, err = io.ReadAll(huffman.NewDecoder(bytes.NewReader([]byte{0xff}), code, 1))
+	if !errors.Is(err, huffman.ErrCorrupt) {
+		t.Fatal(err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := huffman.BuildCode(new(bytemap.Int)); err != huffman.ErrNoSymbols {
+		t.Fatal(err)
+	}
+	for _, lengths := range []map[byte]int{
+		{'a': -1},
+		{'a': 16},
+		{'a': 1, 'b': 1, 'c': 1},
+	} {
+		var m bytemap.Int
+		for k, v := range lengths {
+			m[k] = v
+		}
+		if _, err := huffman.FromLengths(&m); err == nil {
+			t.Errorf("FromLengths(%v) should fail", lengths)
+		}
+	}
+	var counts bytemap.Int
+	counts.WriteString("aab")
+	code, err := huffman.BuildCode(&counts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	enc := huffman.NewEncoder(&buf, code)
+	n, err := enc.Write([]byte("abca"))
+	var ibe *bytemap.InvalidByteError
+	if n != 2 || !errors.As(err, &ibe) || ibe.Byte != 'c' || ibe.Offset != 2 {
+		t.Fatal(n, err)
+	}
+	if err = enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = enc.Write([]byte("a")); err == nil {
+		t.Fatal("write after Close should fail")
+	}
+	// Ask for more bytes than were encoded
+	_, err = io.ReadAll(huffman.NewDecoder(&buf, code, 100))
+	if err != io.ErrUnexpectedEOF {
+		t.Fatal(err)
+	}
+}
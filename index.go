@@ -0,0 +1,60 @@
+package bytemap
+
+import "unsafe"
+
+// bytesOf returns the bytes of s without copying.
+// The result must not be modified.
+func bytesOf[S byteseq](s S) []byte {
+	switch v := any(s).(type) {
+	case []byte:
+		return v
+	case string:
+		return unsafe.Slice(unsafe.StringData(v), len(v))
+	}
+	panic("unreachable")
+}
+
+// bitFieldIndexGeneric returns the index of the first byte of b
+// whose membership in m is in, or -1 if there is none.
+func bitFieldIndexGeneric(m *BitField, b []byte, in bool) int {
+	if in {
+		return indexFunc(b, func(c byte) bool { return m[c/8]&(1<<(c%8)) != 0 })
+	}
+	return indexFunc(b, func(c byte) bool { return m[c/8]&(1<<(c%8)) == 0 })
+}
+
+const (
+	// histogramMin is the length at which Int.Write
+	// uses countHistograms.
+	histogramMin = 1024
+	// histogramChunk limits the bytes counted into each set of sub-histograms
+	// so that the counts fit in a uint32.
+	histogramChunk = 1 << 24
+)
+
+// countHistograms adds the counts of the bytes in b to m.
+// Runs of the same byte make a single histogram stall,
+// because each increment must wait for the previous one to be stored,
+// so it spreads the increments across four sub-histograms
+// and sums them at the end.
+func countHistograms(m *Int, b []byte) {
+	var h [4][Len]uint32
+	for len(b) > 0 {
+		chunk := b[:min(len(b), histogramChunk)]
+		b = b[len(chunk):]
+		for len(chunk) >= 4 {
+			h[0][chunk[0]]++
+			h[1][chunk[1]]++
+			h[2][chunk[2]]++
+			h[3][chunk[3]]++
+			chunk = chunk[4:]
+		}
+		for _, c := range chunk {
+			h[0][c]++
+		}
+		for i := range Len {
+			m[i] += int(h[0][i]) + int(h[1][i]) + int(h[2][i]) + int(h[3][i])
+		}
+		h = [4][Len]uint32{}
+	}
+}
@@ -0,0 +1,102 @@
+//go:build !purego
+
+#include "textflag.h"
+
+DATA lowNibble<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA lowNibble<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL lowNibble<>(SB), RODATA|NOPTR, $16
+
+DATA highBit<>+0(SB)/8, $0x8080808080808080
+DATA highBit<>+8(SB)/8, $0x8080808080808080
+GLOBL highBit<>(SB), RODATA|NOPTR, $16
+
+DATA lowThree<>+0(SB)/8, $0x0707070707070707
+DATA lowThree<>+8(SB)/8, $0x0707070707070707
+GLOBL lowThree<>(SB), RODATA|NOPTR, $16
+
+// bitMask[i] is 1 << (i % 8)
+DATA bitMask<>+0(SB)/8, $0x8040201008040201
+DATA bitMask<>+8(SB)/8, $0x8040201008040201
+GLOBL bitMask<>(SB), RODATA|NOPTR, $16
+
+// func cpuidSSSE3() bool
+TEXT ·cpuidSSSE3(SB), NOSPLIT, $0-1
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	SHRL $9, CX
+	ANDL $1, CX
+	MOVB CX, ret+0(FP)
+	RET
+
+// func bitFieldIndexSSSE3(m *BitField, b []byte, in bool) int
+//
+// For each byte c, the bit field entry m[c>>3] is looked up
+// in X0 (entries 0 to 15) or X1 (entries 16 to 31).
+// PSHUFB uses the low four bits of each index
+// and yields zero when its high bit is set,
+// so c's own high bit selects between the two halves.
+TEXT ·bitFieldIndexSSSE3(SB), NOSPLIT, $0-48
+	MOVQ   m+0(FP), AX
+	MOVQ   b_base+8(FP), SI
+	MOVQ   b_len+16(FP), CX
+	MOVBLZX in+32(FP), DX
+	MOVOU  0(AX), X0
+	MOVOU  16(AX), X1
+	MOVOU  lowNibble<>(SB), X2
+	MOVOU  highBit<>(SB), X3
+	MOVOU  lowThree<>(SB), X4
+	MOVOU  bitMask<>(SB), X5
+	PXOR   X6, X6
+
+	// The movemask below has a bit set for each byte not in m.
+	// To find bytes in m instead, flip its sixteen bits.
+	NEGL DX
+	ANDL $0xffff, DX
+
+	ANDQ $~15, CX
+	XORQ DI, DI
+
+loop:
+	CMPQ DI, CX
+	JAE  notfound
+	MOVOU (SI)(DI*1), X7
+
+	// X8 = (c >> 3) & 15 | c & 0x80
+	MOVO  X7, X8
+	PSRLW $3, X8
+	PAND  X2, X8
+	MOVO  X7, X9
+	PAND  X3, X9
+	POR   X9, X8
+
+	// X10 = m[c >> 3]
+	MOVO   X0, X10
+	PSHUFB X8, X10
+	PXOR   X3, X8
+	MOVO   X1, X11
+	PSHUFB X8, X11
+	POR    X11, X10
+
+	// X12 = 1 << (c & 7)
+	PAND   X4, X7
+	MOVO   X5, X12
+	PSHUFB X7, X12
+
+	PAND     X12, X10
+	PCMPEQB  X6, X10
+	PMOVMSKB X10, BX
+	XORL     DX, BX
+	JNZ      found
+	ADDQ     $16, DI
+	JMP      loop
+
+found:
+	BSFL BX, BX
+	ADDQ DI, BX
+	MOVQ BX, ret+40(FP)
+	RET
+
+notfound:
+	MOVQ $-1, ret+40(FP)
+	RET
@@ -0,0 +1,38 @@
+//go:build !purego
+
+package bytemap
+
+import "unsafe"
+
+// bitFieldIndexNEON is like bitFieldIndex,
+// but only looks at the first len(b) &^ 15 bytes of b.
+//
+//go:noescape
+func bitFieldIndexNEON(m *BitField, b []byte, in bool) int
+
+// bitFieldIndex returns the index of the first byte of b
+// whose membership in m is in, or -1 if there is none.
+// It checks 16 bytes at a time by using TBL
+// to look up each byte's bit field entry in registers.
+func bitFieldIndex(m *BitField, b []byte, in bool) int {
+	if len(b) < 16 {
+		return bitFieldIndexGeneric(m, b, in)
+	}
+	if i := bitFieldIndexNEON(m, b, in); i != -1 {
+		return i
+	}
+	n := len(b) &^ 15
+	if i := bitFieldIndexGeneric(m, b[n:], in); i != -1 {
+		return n + i
+	}
+	return -1
+}
+
+// bitSet256Index returns the index of the first byte of b
+// whose membership in m is in, or -1 if there is none.
+// On a little-endian machine, the words of a BitSet256
+// have the same layout in memory as a BitField,
+// so it can use the same lookup.
+func bitSet256Index(m *BitSet256, b []byte, in bool) int {
+	return bitFieldIndex((*BitField)(unsafe.Pointer(m)), b, in)
+}
@@ -0,0 +1,68 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// bitMask[i] is 1 << (i % 8)
+DATA bitMask<>+0(SB)/8, $0x8040201008040201
+DATA bitMask<>+8(SB)/8, $0x8040201008040201
+GLOBL bitMask<>(SB), RODATA|NOPTR, $16
+
+// func bitFieldIndexNEON(m *BitField, b []byte, in bool) int
+//
+// For each byte c, the bit field entry m[c>>3] is looked up
+// in V0 and V1 together, which TBL treats as one 32 entry table.
+TEXT ·bitFieldIndexNEON(SB), NOSPLIT, $0-48
+	MOVD  m+0(FP), R0
+	MOVD  b_base+8(FP), R1
+	MOVD  b_len+16(FP), R2
+	MOVBU in+32(FP), R3
+	VLD1  (R0), [V0.B16, V1.B16]
+	MOVD  $bitMask<>(SB), R4
+	VLD1  (R4), [V2.B16]
+	VMOVI $7, V3.B16
+
+	// The test below sets each byte in m to all ones.
+	// To find bytes not in m instead, flip every bit.
+	SUB $1, R3
+
+	AND  $~15, R2
+	MOVD ZR, R5
+
+loop:
+	CMP    R2, R5
+	BHS    notfound
+	VLD1.P 16(R1), [V4.B16]
+
+	// V6 = m[c >> 3]
+	VUSHR $3, V4.B16, V5.B16
+	VTBL  V5.B16, [V0.B16, V1.B16], V6.B16
+
+	// V7 = 1 << (c & 7)
+	VAND V3.B16, V4.B16, V7.B16
+	VTBL V7.B16, [V2.B16], V7.B16
+
+	VCMTST V7.B16, V6.B16, V6.B16
+	VMOV   V6.D[0], R6
+	VMOV   V6.D[1], R7
+	EOR    R3, R6
+	EOR    R3, R7
+	CBNZ   R6, found
+	CBNZ   R7, foundHigh
+	ADD    $16, R5
+	B      loop
+
+foundHigh:
+	ADD  $8, R5
+	MOVD R7, R6
+
+found:
+	RBIT R6, R6
+	CLZ  R6, R6
+	ADD  R6>>3, R5, R5
+	MOVD R5, ret+40(FP)
+	RET
+
+notfound:
+	MOVD $-1, R5
+	MOVD R5, ret+40(FP)
+	RET
@@ -0,0 +1,18 @@
+//go:build !(amd64 || arm64) || purego
+
+package bytemap
+
+// bitFieldIndex returns the index of the first byte of b
+// whose membership in m is in, or -1 if there is none.
+func bitFieldIndex(m *BitField, b []byte, in bool) int {
+	return bitFieldIndexGeneric(m, b, in)
+}
+
+// bitSet256Index returns the index of the first byte of b
+// whose membership in m is in, or -1 if there is none.
+func bitSet256Index(m *BitSet256, b []byte, in bool) int {
+	if in {
+		return indexFunc(b, func(c byte) bool { return m[c>>6]&(1<<(c&63)) != 0 })
+	}
+	return indexFunc(b, func(c byte) bool { return m[c>>6]&(1<<(c&63)) == 0 })
+}
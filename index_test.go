@@ -0,0 +1,92 @@
+package bytemap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+type indexer interface {
+	Get(byte) bool
+	IndexAny(string) int
+	IndexAnyBytes([]byte) int
+	IndexNotIn(string) int
+	Contains(string) bool
+	ContainsBytes([]byte) bool
+}
+
+// FuzzIndex checks the accelerated BitField and BitSet256 searches
+// against simple loops over Get, at every alignment.
+func FuzzIndex(f *testing.F) {
+	f.Add("0123456789", "12345678901234567890123456789x")
+	f.Add("\x00\x7f\x80\xff", strings.Repeat("\x00\x7f\x80\xff", 10)+"\x01")
+	f.Add("abc", "")
+	f.Fuzz(func(t *testing.T, set, s string) {
+		b := bytemap.Make(set)
+		for _, m := range []indexer{b.ToBitField(), b.ToBitSet256()} {
+			testIndex(t, m, s)
+		}
+	})
+}
+
+func testIndex(t *testing.T, m indexer, s string) {
+	t.Helper()
+	for start := range min(len(s), 17) {
+		sub := s[start:]
+		wantAny, wantNotIn := slowIndex(m, sub, true), slowIndex(m, sub, false)
+		if got := m.IndexAny(sub); got != wantAny {
+			t.Fatalf("IndexAny(%q) = %d; want %d", sub, got, wantAny)
+		}
+		if got := m.IndexAnyBytes([]byte(sub)); got != wantAny {
+			t.Fatalf("IndexAnyBytes(%q) = %d; want %d", sub, got, wantAny)
+		}
+		if got := m.IndexNotIn(sub); got != wantNotIn {
+			t.Fatalf("IndexNotIn(%q) = %d; want %d", sub, got, wantNotIn)
+		}
+		if got := m.Contains(sub); got != (wantNotIn == -1) {
+			t.Fatalf("Contains(%q) = %v", sub, got)
+		}
+		if got := m.ContainsBytes([]byte(sub)); got != (wantNotIn == -1) {
+			t.Fatalf("ContainsBytes(%q) = %v", sub, got)
+		}
+	}
+}
+
+func slowIndex(m indexer, s string, in bool) int {
+	for i := range len(s) {
+		if m.Get(s[i]) == in {
+			return i
+		}
+	}
+	return -1
+}
+
+// FuzzIntWrite checks counting with sub-histograms
+// against a simple loop.
+func FuzzIntWrite(f *testing.F) {
+	f.Add("hello, world", 1)
+	f.Add("\x00", 5000)
+	f.Fuzz(func(t *testing.T, s string, repeat int) {
+		repeat = 1 + abs(repeat)%5000
+		s = strings.Repeat(s, repeat)
+		var want bytemap.Int
+		for i := range len(s) {
+			want[s[i]]++
+		}
+		var got bytemap.Int
+		got.Write([]byte(s))
+		if !got.Equals(&want) {
+			t.Fatalf("Write(%q...) = %v", s[:min(len(s), 20)], got.ToMap())
+		}
+		got = bytemap.Int{}
+		got.WriteString(s)
+		got.WriteString(s)
+		for i := range want {
+			want[i] *= 2
+		}
+		if !got.Equals(&want) {
+			t.Fatalf("WriteString(%q...) = %v", s[:min(len(s), 20)], got.ToMap())
+		}
+	})
+}
@@ -11,6 +11,10 @@ var _ io.Writer = (*Int)(nil)
 
 // Write satisfies io.Writer.
 func (m *Int) Write(p []byte) (int, error) {
+	if len(p) >= histogramMin {
+		countHistograms(m, p)
+		return len(p), nil
+	}
 	for _, c := range p {
 		m[c]++
 	}
@@ -21,6 +25,10 @@ var _ io.StringWriter = (*Int)(nil)
 
 // WriteString satisfies io.StringWriter.
 func (m *Int) WriteString(s string) (n int, err error) {
+	if len(s) >= histogramMin {
+		countHistograms(m, bytesOf(s))
+		return len(s), nil
+	}
 	for _, c := range []byte(s) {
 		m[c]++
 	}
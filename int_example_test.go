@@ -5,6 +5,8 @@ package bytemap_test
 import (
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"slices"
 	"strings"
 
 	"github.com/earthboundkid/bytemap/v2"
@@ -50,3 +52,69 @@ func ExampleInt_MostCommon() {
 	// 'y': 1
 	// 'z': 1
 }
+
+func ExampleCollectCounts() {
+	words := []string{"apple", "banana", "cherry"}
+	ends := func(yield func(byte) bool) {
+		for _, w := range words {
+			if !yield(w[0]) || !yield(w[len(w)-1]) {
+				return
+			}
+		}
+	}
+	m := bytemap.CollectCounts(ends)
+	for c, n := range m.All() {
+		if n > 0 {
+			fmt.Printf("%q: %d\n", c, n)
+		}
+	}
+	fmt.Printf("%q\n", slices.Collect(m.Keys()))
+	// Output:
+	// 'a': 2
+	// 'b': 1
+	// 'c': 1
+	// 'e': 1
+	// 'y': 1
+	// "abcey"
+}
+
+func ExampleInt_ChiSquared() {
+	var reference, upload bytemap.Int
+	reference.WriteString(strings.Repeat("the quick brown fox jumps over the lazy dog ", 100))
+
+	upload.WriteString("the lazy dog jumps over the quick brown fox")
+	_, p := upload.ChiSquared(&reference)
+	fmt.Printf("text: p=%.2f\n", p)
+
+	upload = bytemap.Int{}
+	upload.WriteString("zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz")
+	_, p = upload.ChiSquared(&reference)
+	fmt.Printf("zs:   p=%.2f\n", p)
+	// Output:
+	// text: p=1.00
+	// zs:   p=0.00
+}
+
+func ExampleInt_Above() {
+	var counts bytemap.Int
+	counts.WriteString("GET /index.html GET /about.html GET /%7Euser")
+	// Allow bytes seen at least three times
+	allow := counts.Above(2)
+	fmt.Printf("%q\n", slices.Collect(allow.Keys()))
+	fmt.Println(allow.Contains("GET /"), allow.Contains("%7E"))
+	// Output:
+	// " /EGTt"
+	// true false
+}
+
+func ExampleInt_Sampler() {
+	var counts bytemap.Int
+	counts.WriteString("GET POST GET GET PUT")
+	sampler, err := counts.Sampler(rand.NewPCG(1, 2))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%q\n", sampler.Generate(20))
+	// Output:
+	// "TPTTE GG  SGT GTTG T"
+}
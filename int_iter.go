@@ -26,3 +26,45 @@ func (m *Int) MostCommon() iter.Seq2[byte, int] {
 		}
 	}
 }
+
+// All returns a sequence of every byte and its value in m, in byte order.
+func (m *Int) All() iter.Seq2[byte, int] {
+	return func(yield func(byte, int) bool) {
+		for i, v := range m {
+			if !yield(byte(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the bytes with non-zero values in m, in byte order.
+func (m *Int) Keys() iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		for i, v := range m {
+			if v != 0 && !yield(byte(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the value for every byte in m, in byte order.
+func (m *Int) Values() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// CollectCounts creates a bytemap.Int counting the bytes in seq.
+func CollectCounts(seq iter.Seq[byte]) *Int {
+	var m Int
+	for c := range seq {
+		m[c]++
+	}
+	return &m
+}
@@ -0,0 +1,60 @@
+// Package textclass holds the byte classes which bytemap and sniff
+// use to tell text from binary data and UTF-8 from other encodings.
+//
+// Sets and counts are plain arrays with the same layout
+// as bytemap.Bool and bytemap.Int, so either package can convert to them.
+package textclass
+
+func span(sets ...[2]byte) *[256]bool {
+	var m [256]bool
+	for _, s := range sets {
+		for c := int(s[0]); c <= int(s[1]); c++ {
+			m[c] = true
+		}
+	}
+	return &m
+}
+
+var (
+	// High is the bytes outside of ASCII.
+	High = span([2]byte{0x80, 0xff})
+	// Control is the control characters which are rare in text.
+	// Tab, line feed, vertical tab, form feed, carriage return,
+	// backspace, and escape are common enough to allow,
+	// and NUL is left for the caller to judge.
+	Control = span([2]byte{0x01, 0x07}, [2]byte{0x0e, 0x1a}, [2]byte{0x1c, 0x1f}, [2]byte{0x7f, 0x7f})
+	// Continuation is the bytes which continue a UTF-8 sequence.
+	Continuation = span([2]byte{0x80, 0xbf})
+	// Lead2, Lead3, and Lead4 are the bytes which begin
+	// a UTF-8 sequence of two, three, and four bytes.
+	Lead2 = span([2]byte{0xc2, 0xdf})
+	Lead3 = span([2]byte{0xe0, 0xef})
+	Lead4 = span([2]byte{0xf0, 0xf4})
+	// NeverUTF8 is the bytes which never appear in valid UTF-8.
+	NeverUTF8 = span([2]byte{0xc0, 0xc1}, [2]byte{0xf5, 0xff})
+)
+
+// Sum returns the sum of the positive counts for the bytes in set.
+func Sum(counts *[256]int, set *[256]bool) int {
+	n := 0
+	for i, v := range counts {
+		if set[i] && v > 0 {
+			n += v
+		}
+	}
+	return n
+}
+
+// UTF8Mismatches returns how many of the counted bytes
+// are inconsistent with UTF-8:
+// the bytes which never appear in UTF-8,
+// plus the difference between the number of continuation bytes
+// and the number which the lead bytes call for.
+// It is 0 for the counts of valid UTF-8,
+// though counts can be consistent without the bytes being valid.
+func UTF8Mismatches(counts *[256]int) int {
+	// Every lead byte must be followed by the right number of continuations
+	expected := Sum(counts, Lead2) + 2*Sum(counts, Lead3) + 3*Sum(counts, Lead4)
+	diff := Sum(counts, Continuation) - expected
+	return Sum(counts, NeverUTF8) + max(diff, -diff)
+}
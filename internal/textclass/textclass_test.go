@@ -0,0 +1,45 @@
+package textclass_test
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/earthboundkid/bytemap/v2/internal/textclass"
+)
+
+func count(s string) *[256]int {
+	var counts [256]int
+	for _, c := range []byte(s) {
+		counts[c]++
+	}
+	return &counts
+}
+
+func TestUTF8Mismatches(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want int
+	}{
+		{"plain", 0},
+		{"日本語", 0},
+		{"caf\xe9!", 2},
+		{"\xff\xfe", 2},
+		{"a\x80", 1},
+	} {
+		if got := textclass.UTF8Mismatches(count(tc.s)); got != tc.want {
+			t.Errorf("UTF8Mismatches(%q) = %d; want %d", tc.s, got, tc.want)
+		}
+	}
+}
+
+func FuzzUTF8Mismatches(f *testing.F) {
+	f.Add("naïve café — “quoted” 日本語 🙂")
+	f.Add("\xe6\x97")
+	f.Fuzz(func(t *testing.T, s string) {
+		if utf8.ValidString(s) {
+			if n := textclass.UTF8Mismatches(count(s)); n != 0 {
+				t.Fatalf("UTF8Mismatches(%q) = %d", s, n)
+			}
+		}
+	})
+}
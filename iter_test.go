@@ -0,0 +1,90 @@
+//go:build go1.23 || goexperiment.rangefunc
+
+package bytemap_test
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+type iterable[V comparable] interface {
+	All() iter.Seq2[byte, V]
+	Values() iter.Seq[V]
+	ToMap() map[byte]V
+}
+
+func testIter[V comparable](t *testing.T, m iterable[V], wantKeys []byte, keys iter.Seq[byte]) {
+	t.Helper()
+	if !maps.Equal(maps.Collect(m.All()), m.ToMap()) {
+		t.Fatal("All does not match ToMap")
+	}
+	values := slices.Collect(m.Values())
+	if len(values) != bytemap.Len {
+		t.Fatal(len(values))
+	}
+	for c, v := range m.All() {
+		if values[c] != v {
+			t.Fatal(c, values[c], v)
+		}
+	}
+	if keys != nil {
+		if got := slices.Collect(keys); !slices.Equal(got, wantKeys) {
+			t.Fatalf("Keys: got %q; want %q", got, wantKeys)
+		}
+		// Stopping early must not panic
+		for range keys {
+			break
+		}
+	}
+	for range m.All() {
+		break
+	}
+	for range m.Values() {
+		break
+	}
+}
+
+func FuzzIter(f *testing.F) {
+	f.Add("")
+	f.Add("a")
+	f.Add("hello, world")
+	f.Add("\x00\x3f\x40\x7f\x80\xbf\xc0\xff")
+	f.Fuzz(func(t *testing.T, s string) {
+		var keys []byte
+		for c := range bytemap.Len {
+			if naiveMap(s)[byte(c)] {
+				keys = append(keys, byte(c))
+			}
+		}
+		m := bytemap.Make(s)
+		testIter(t, m, keys, m.Keys())
+		bf := m.ToBitField()
+		testIter(t, bf, keys, bf.Keys())
+		bs := m.ToBitSet256()
+		testIter(t, bs, keys, bs.Keys())
+		var mInt bytemap.Int
+		mInt.WriteString(s)
+		testIter(t, &mInt, keys, mInt.Keys())
+		var mFloat bytemap.Float
+		mFloat.WriteString(s)
+		testIter(t, &mFloat, keys, mFloat.Keys())
+		mMap := bytemap.NewCounter[uint16]()
+		mMap.WriteString(s)
+		testIter(t, mMap, keys, mMap.Keys())
+		testIter(t, bytemap.Translate("a-z", "A-Z"), nil, nil)
+
+		if !bytemap.Collect(slices.Values([]byte(s))).Equals(m) {
+			t.Fatal("Collect", s)
+		}
+		if !bytemap.Collect(m.Keys()).Equals(m) {
+			t.Fatal("Collect(Keys)", s)
+		}
+		if !bytemap.CollectCounts(slices.Values([]byte(s))).Equals(&mInt) {
+			t.Fatal("CollectCounts", s)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package bytemap
+
+import (
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+// Map is a generic array backed map from byte to V.
+//
+// A byte is present in a Map if the Map's present predicate reports true
+// for its value. Writing a byte to a Map replaces its value with the result
+// of the Map's increment function. Use NewMap or NewCounter to create a Map
+// with those functions set.
+//
+// The zero value of Map treats any non-zero value as present,
+// but it has no increment function, so Write and WriteString panic.
+// Use Set to fill in a zero Map instead.
+//
+// Bool, Int, and Float are specialized equivalents of
+// Map[bool], Map[int], and Map[float64].
+type Map[V any] struct {
+	values    [Len]V
+	present   func(V) bool
+	increment func(V) V
+}
+
+// NewMap creates a Map which uses present to determine
+// whether a value counts as containing its byte
+// and increment to update a value when its byte is written.
+// If present is nil, any non-zero value is treated as present.
+func NewMap[V any](present func(V) bool, increment func(V) V) *Map[V] {
+	if present == nil {
+		present = nonZero[V]()
+	}
+	return &Map[V]{present: present, increment: increment}
+}
+
+// Number is a constraint for the numeric types that NewCounter can count with.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// NewCounter creates a Map which counts writes like Int.
+// A byte is present if its value is greater than zero.
+func NewCounter[V Number]() *Map[V] {
+	return NewMap(
+		func(v V) bool { return v > 0 },
+		func(v V) V { return v + 1 },
+	)
+}
+
+// presentFunc returns the present predicate of m,
+// or for a zero Map a default which treats any non-zero value as present.
+// Callers should look it up once rather than for every byte.
+func (m *Map[V]) presentFunc() func(V) bool {
+	if m.present != nil {
+		return m.present
+	}
+	return nonZero[V]()
+}
+
+// nonZero returns a predicate reporting whether a V is not its zero value.
+// Scalar types are loaded directly as a same-sized type,
+// and only other types fall back to reflect.
+func nonZero[V any]() func(V) bool {
+	t := reflect.TypeFor[V]()
+	switch t.Kind() {
+	case reflect.Float32:
+		return func(v V) bool { return *(*float32)(unsafe.Pointer(&v)) != 0 }
+	case reflect.Float64:
+		return func(v V) bool { return *(*float64)(unsafe.Pointer(&v)) != 0 }
+	case reflect.String:
+		return func(v V) bool { return *(*string)(unsafe.Pointer(&v)) != "" }
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Pointer, reflect.UnsafePointer,
+		reflect.Chan, reflect.Func, reflect.Map:
+		switch t.Size() {
+		case 1:
+			return func(v V) bool { return *(*uint8)(unsafe.Pointer(&v)) != 0 }
+		case 2:
+			return func(v V) bool { return *(*uint16)(unsafe.Pointer(&v)) != 0 }
+		case 4:
+			return func(v V) bool { return *(*uint32)(unsafe.Pointer(&v)) != 0 }
+		case 8:
+			return func(v V) bool { return *(*uint64)(unsafe.Pointer(&v)) != 0 }
+		}
+	}
+	return func(v V) bool {
+		return !reflect.ValueOf(&v).Elem().IsZero()
+	}
+}
+
+func (m *Map[V]) incrementFunc() func(V) V {
+	if m.increment == nil {
+		panic("bytemap: write to Map with no increment function; use NewMap or NewCounter")
+	}
+	return m.increment
+}
+
+var _ io.Writer = (*Map[int])(nil)
+
+// Write satisfies io.Writer.
+func (m *Map[V]) Write(p []byte) (int, error) {
+	increment := m.incrementFunc()
+	for _, c := range p {
+		m.values[c] = increment(m.values[c])
+	}
+	return len(p), nil
+}
+
+var _ io.StringWriter = (*Map[int])(nil)
+
+// WriteString satisfies io.StringWriter.
+func (m *Map[V]) WriteString(s string) (n int, err error) {
+	increment := m.incrementFunc()
+	for _, c := range []byte(s) {
+		m.values[c] = increment(m.values[c])
+	}
+	return len(s), nil
+}
+
+// Contains reports whether all bytes in s are already in m.
+func (m *Map[V]) Contains(s string) bool {
+	present := m.presentFunc()
+	for _, c := range []byte(s) {
+		if !present(m.values[c]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsBytes reports whether all bytes in b are already in m.
+func (m *Map[V]) ContainsBytes(b []byte) bool {
+	present := m.presentFunc()
+	for _, c := range b {
+		if !present(m.values[c]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsReader reports whether all bytes in r are already in m.
+// If the reader fails, it returns false, error.
+// If it reads to io.EOF, it returns true, nil.
+func (m *Map[V]) ContainsReader(r io.Reader) (bool, error) {
+	var buf [4096]byte
+	for {
+		n, err := r.Read(buf[:])
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		if !m.ContainsBytes(buf[:n]) {
+			return false, nil
+		}
+		if err == io.EOF {
+			return true, nil
+		}
+	}
+}
+
+// ToMap makes a map[byte]V from the bytemap.
+func (m *Map[V]) ToMap() map[byte]V {
+	m2 := make(map[byte]V)
+	for i := range m.values {
+		m2[byte(i)] = m.values[i]
+	}
+	return m2
+}
+
+// ToBool makes a Bool of the bytes present in the bytemap.
+func (m *Map[V]) ToBool() *Bool {
+	var m2 Bool
+	present := m.presentFunc()
+	for i := range m.values {
+		m2[byte(i)] = present(m.values[i])
+	}
+	return &m2
+}
+
+// EqualMaps reports if two Maps have equal values.
+func EqualMaps[V comparable](a, b *Map[V]) bool {
+	return a.values == b.values
+}
+
+// Set sets one byte in the Map byte map.
+func (m *Map[V]) Set(key byte, value V) {
+	m.values[key] = value
+}
+
+// Get looks up one byte in the Map byte map.
+func (m *Map[V]) Get(key byte) V {
+	return m.values[key]
+}
+
+// Clone copies m.
+func (m *Map[V]) Clone() *Map[V] {
+	m2 := *m
+	return &m2
+}
@@ -0,0 +1,33 @@
+package bytemap_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func ExampleNewCounter() {
+	m := bytemap.NewCounter[uint8]()
+	m.WriteString("mississippi")
+	fmt.Println(m.Get('s'), m.Get('p'), m.Get('x'))
+	fmt.Println(m.Contains("sip"), m.Contains("six"))
+	// Output:
+	// 4 2 0
+	// true false
+}
+
+func ExampleNewMap() {
+	// Track the last time each byte was seen.
+	var now time.Duration
+	m := bytemap.NewMap(nil, func(time.Duration) time.Duration {
+		return now
+	})
+	for _, s := range []string{"ab", "bc", "cd"} {
+		now += time.Second
+		m.WriteString(s)
+	}
+	fmt.Println(m.Get('a'), m.Get('b'), m.Get('d'), m.Get('e'))
+	// Output:
+	// 1s 2s 3s 0s
+}
@@ -0,0 +1,41 @@
+//go:build go1.23 || goexperiment.rangefunc
+
+package bytemap
+
+import (
+	"iter"
+)
+
+// All returns a sequence of every byte and its value in m, in byte order.
+func (m *Map[V]) All() iter.Seq2[byte, V] {
+	return func(yield func(byte, V) bool) {
+		for i, v := range m.values {
+			if !yield(byte(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the bytes present in m, in byte order.
+func (m *Map[V]) Keys() iter.Seq[byte] {
+	present := m.presentFunc()
+	return func(yield func(byte) bool) {
+		for i, v := range m.values {
+			if present(v) && !yield(byte(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the value for every byte in m, in byte order.
+func (m *Map[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
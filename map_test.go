@@ -0,0 +1,185 @@
+package bytemap_test
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func FuzzMap(f *testing.F) {
+	f.Add("", "")
+	f.Add("a", "a")
+	f.Add("a", "b")
+	f.Add("ab", "ab")
+	f.Add("ab", "abc")
+	for i := 0; i < 1_000_000; i = (i + 1) * 2 {
+		for j := 0; j < 3; j++ {
+			s := strings.Repeat("a", i)
+			charset := strings.Repeat("a", j)
+			f.Add(s, charset)
+			f.Add(s+"b", charset)
+		}
+	}
+	f.Fuzz(func(t *testing.T, s, charset string) {
+		want := naiveContains(s, charset)
+		t.Run("WriteString", func(t *testing.T) {
+			m := bytemap.NewCounter[uint8]()
+			n, err := m.WriteString(charset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(charset) {
+				t.Fatal(len(charset))
+			}
+			testContainment(t, m, s, charset, want)
+		})
+		t.Run("Write", func(t *testing.T) {
+			m := bytemap.NewCounter[int32]()
+			n, err := m.Write([]byte(charset))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(charset) {
+				t.Fatal(len(charset))
+			}
+			testContainment(t, m, s, charset, want)
+		})
+		// Test io.Copy
+		t.Run("Copy", func(t *testing.T) {
+			m := bytemap.NewCounter[time.Duration]()
+			n64, err := io.Copy(m, strings.NewReader(charset))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n64 != int64(len(charset)) {
+				t.Fatal(len(charset))
+			}
+			testContainment(t, m, s, charset, want)
+		})
+		t.Run("MatchesInt", func(t *testing.T) {
+			var mInt bytemap.Int
+			mInt.WriteString(charset)
+			m := bytemap.NewCounter[int]()
+			m.WriteString(charset)
+			if !maps.Equal(m.ToMap(), mInt.ToMap()) {
+				t.Fatal(m.ToMap(), mInt.ToMap())
+			}
+			if !m.ToBool().Equals(mInt.ToBool()) {
+				t.Fatal(m.ToBool(), mInt.ToBool())
+			}
+		})
+	})
+}
+
+func FuzzMapSet(f *testing.F) {
+	f.Add("", "", "")
+	f.Add("a", "a", "a")
+	f.Add("abc", "bcde", "b")
+	f.Fuzz(func(t *testing.T, add, remove, restore string) {
+		var mMap bytemap.Map[uint64]
+		m := make(map[byte]uint64)
+		for _, c := range []byte(add) {
+			mMap.Set(c, 1)
+			m[c] = 1
+		}
+		for _, c := range []byte(remove) {
+			mMap.Set(c, 0)
+			m[c] = 0
+		}
+		for _, c := range []byte(restore) {
+			mMap.Set(c, 1)
+			m[c] = 1
+		}
+		// Fill in blanks
+		for i := 0; i < bytemap.Len; i++ {
+			m[byte(i)] = m[byte(i)]
+		}
+		testGet(t, &mMap, m)
+		if !maps.Equal(mMap.ToMap(), m) {
+			t.Fatal(mMap)
+		}
+		m2 := mMap.Clone()
+		if !bytemap.EqualMaps(m2, &mMap) {
+			t.Fatal(m2, mMap)
+		}
+	})
+}
+
+func TestMapStruct(t *testing.T) {
+	type stat struct {
+		n    int
+		seen bool
+	}
+	m := bytemap.NewMap(
+		func(s stat) bool { return s.seen },
+		func(s stat) stat { return stat{s.n + 1, true} },
+	)
+	m.WriteString("hello")
+	if got := m.Get('l'); got != (stat{2, true}) {
+		t.Fatal(got)
+	}
+	if !m.Contains("hole") || m.Contains("help") {
+		t.Fatal(m.ToBool())
+	}
+	m.Set('p', stat{0, true})
+	if !m.Contains("help") {
+		t.Fatal(m.ToBool())
+	}
+	if !m.ToBool().Equals(bytemap.Make("helop")) {
+		t.Fatal(m.ToBool())
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	m := bytemap.NewMap(nil, func(s []int) []int { return append(s, len(s)) })
+	m.WriteString("hello")
+	if got := m.Get('l'); !slices.Equal(got, []int{0, 1}) {
+		t.Fatal(got)
+	}
+	if !m.Contains("hole") || m.Contains("help") {
+		t.Fatal(m.ToBool())
+	}
+	m.Set('l', []int{})
+	if !m.Contains("hole") {
+		t.Fatal(m.ToBool())
+	}
+}
+
+func testZeroMap[V any](t *testing.T, nonzero V) {
+	t.Helper()
+	var m bytemap.Map[V]
+	m.Set('a', nonzero)
+	if !m.Contains("aa") || m.Contains("ab") || !m.ToBool().Equals(bytemap.Make("a")) {
+		t.Fatalf("Map[%T]: %v", nonzero, m.ToBool())
+	}
+	if !slices.Equal(slices.Collect(m.Keys()), []byte("a")) {
+		t.Fatalf("Map[%T]: keys %v", nonzero, slices.Collect(m.Keys()))
+	}
+}
+
+func TestMapZero(t *testing.T) {
+	testZeroMap(t, true)
+	testZeroMap(t, int8(-1))
+	testZeroMap(t, uint16(1<<15))
+	testZeroMap(t, int32(1))
+	testZeroMap(t, time.Duration(1))
+	testZeroMap(t, 0.5)
+	testZeroMap(t, float32(-1))
+	testZeroMap(t, "x")
+	testZeroMap(t, new(int))
+	testZeroMap(t, [2]int{0, 1})
+	testZeroMap(t, []int{})
+
+	var m bytemap.Map[int]
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Write to zero Map did not panic")
+		}
+	}()
+	m.WriteString("a")
+}
@@ -0,0 +1,409 @@
+package bytemap
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Bool)(nil)
+	_ encoding.BinaryUnmarshaler = (*Bool)(nil)
+	_ encoding.TextMarshaler     = (*Bool)(nil)
+	_ encoding.TextUnmarshaler   = (*Bool)(nil)
+)
+
+// MarshalBinary satisfies encoding.BinaryMarshaler.
+// The encoding is the same as for the equivalent BitField.
+func (m *Bool) MarshalBinary() ([]byte, error) {
+	return m.ToBitField().MarshalBinary()
+}
+
+// UnmarshalBinary satisfies encoding.BinaryUnmarshaler.
+func (m *Bool) UnmarshalBinary(data []byte) error {
+	var bf BitField
+	if err := bf.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*m = *bf.ToBool()
+	return nil
+}
+
+// MarshalText satisfies encoding.TextMarshaler.
+// The text form is a character class which can be read by ParseClass.
+// The text form is also used for JSON.
+func (m *Bool) MarshalText() ([]byte, error) {
+	return appendClass(nil, m), nil
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler.
+// It accepts any character class that ParseClass does.
+func (m *Bool) UnmarshalText(text []byte) error {
+	m2, err := ParseClass(string(text))
+	if err != nil {
+		return err
+	}
+	*m = *m2
+	return nil
+}
+
+// appendClass appends m to b in the character class syntax of ParseClass.
+func appendClass(b []byte, m *Bool) []byte {
+	n := 0
+	for _, v := range m {
+		if v {
+			n++
+		}
+	}
+	switch {
+	case n == 0:
+		return append(b, `[^\x00-\xff]`...)
+	case n == Len:
+		return append(b, `[\x00-\xff]`...)
+	}
+	b = append(b, '[')
+	if n > Len/2 {
+		b = append(b, '^')
+		m = m.Invert()
+	}
+	for i := 0; i < Len; i++ {
+		if !m[i] {
+			continue
+		}
+		j := i
+		for j+1 < Len && m[j+1] {
+			j++
+		}
+		b = appendClassByte(b, byte(i))
+		switch {
+		case j == i+1:
+			b = appendClassByte(b, byte(j))
+		case j > i+1:
+			b = append(b, '-')
+			b = appendClassByte(b, byte(j))
+		}
+		i = j
+	}
+	return append(b, ']')
+}
+
+func appendClassByte(b []byte, c byte) []byte {
+	switch {
+	case c == '\\' || c == ']' || c == '[' || c == '^' || c == '-':
+		return append(b, '\\', c)
+	case c >= ' ' && c <= '~':
+		return append(b, c)
+	}
+	return fmt.Appendf(b, `\x%02x`, c)
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*BitField)(nil)
+	_ encoding.BinaryUnmarshaler = (*BitField)(nil)
+	_ encoding.TextMarshaler     = (*BitField)(nil)
+	_ encoding.TextUnmarshaler   = (*BitField)(nil)
+)
+
+// MarshalBinary satisfies encoding.BinaryMarshaler.
+// The encoding is the BitFieldLen bytes of the BitField.
+func (m *BitField) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), m[:]...), nil
+}
+
+// UnmarshalBinary satisfies encoding.BinaryUnmarshaler.
+func (m *BitField) UnmarshalBinary(data []byte) error {
+	if len(data) != BitFieldLen {
+		return fmt.Errorf("invalid BitField length: %d", len(data))
+	}
+	copy(m[:], data)
+	return nil
+}
+
+// MarshalText satisfies encoding.TextMarshaler.
+// The text form is a character class which can be read by ParseClass.
+// The text form is also used for JSON.
+func (m *BitField) MarshalText() ([]byte, error) {
+	return m.ToBool().MarshalText()
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler.
+// It accepts any character class that ParseClass does.
+func (m *BitField) UnmarshalText(text []byte) error {
+	var m2 Bool
+	if err := m2.UnmarshalText(text); err != nil {
+		return err
+	}
+	*m = *m2.ToBitField()
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*BitSet256)(nil)
+	_ encoding.BinaryUnmarshaler = (*BitSet256)(nil)
+	_ encoding.TextMarshaler     = (*BitSet256)(nil)
+	_ encoding.TextUnmarshaler   = (*BitSet256)(nil)
+)
+
+// MarshalBinary satisfies encoding.BinaryMarshaler.
+// The encoding is the same as for the equivalent BitField.
+func (m *BitSet256) MarshalBinary() ([]byte, error) {
+	return m.ToBitField().MarshalBinary()
+}
+
+// UnmarshalBinary satisfies encoding.BinaryUnmarshaler.
+func (m *BitSet256) UnmarshalBinary(data []byte) error {
+	var bf BitField
+	if err := bf.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*m = *bf.ToBitSet256()
+	return nil
+}
+
+// MarshalText satisfies encoding.TextMarshaler.
+// The text form is a character class which can be read by ParseClass.
+// The text form is also used for JSON.
+func (m *BitSet256) MarshalText() ([]byte, error) {
+	return m.ToBool().MarshalText()
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler.
+// It accepts any character class that ParseClass does.
+func (m *BitSet256) UnmarshalText(text []byte) error {
+	var m2 Bool
+	if err := m2.UnmarshalText(text); err != nil {
+		return err
+	}
+	*m = *m2.ToBitSet256()
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*Int)(nil)
+	_ encoding.BinaryUnmarshaler = (*Int)(nil)
+	_ encoding.TextMarshaler     = (*Int)(nil)
+	_ encoding.TextUnmarshaler   = (*Int)(nil)
+	_ json.Marshaler             = (*Int)(nil)
+	_ json.Unmarshaler           = (*Int)(nil)
+)
+
+// MarshalBinary satisfies encoding.BinaryMarshaler.
+// The encoding is each count in order as a signed varint.
+func (m *Int) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, Len)
+	for _, n := range m {
+		b = binary.AppendVarint(b, int64(n))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary satisfies encoding.BinaryUnmarshaler.
+func (m *Int) UnmarshalBinary(data []byte) error {
+	var m2 Int
+	for i := range m2 {
+		n, size := binary.Varint(data)
+		if size <= 0 || int64(int(n)) != n {
+			return fmt.Errorf("invalid Int count for byte %d", i)
+		}
+		m2[i] = int(n)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("invalid Int: %d extra bytes", len(data))
+	}
+	*m = m2
+	return nil
+}
+
+// MarshalText satisfies encoding.TextMarshaler.
+// The text form is a space separated list of byte:count pairs
+// for each byte with a non-zero count, such as "97:3 98:1".
+func (m *Int) MarshalText() ([]byte, error) {
+	var b []byte
+	for i, n := range m {
+		if n == 0 {
+			continue
+		}
+		if len(b) > 0 {
+			b = append(b, ' ')
+		}
+		b = strconv.AppendInt(b, int64(i), 10)
+		b = append(b, ':')
+		b = strconv.AppendInt(b, int64(n), 10)
+	}
+	return b, nil
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler.
+func (m *Int) UnmarshalText(text []byte) error {
+	var m2 Int
+	err := parsePairs(string(text), func(c byte, val string) error {
+		n, err := strconv.Atoi(val)
+		m2[c] = n
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	*m = m2
+	return nil
+}
+
+// MarshalJSON satisfies json.Marshaler.
+// The JSON form is an object from byte to count
+// for each byte with a non-zero count, such as {"97":3,"98":1}.
+func (m *Int) MarshalJSON() ([]byte, error) {
+	b := []byte{'{'}
+	for i, n := range m {
+		if n == 0 {
+			continue
+		}
+		if len(b) > 1 {
+			b = append(b, ',')
+		}
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(i), 10)
+		b = append(b, '"', ':')
+		b = strconv.AppendInt(b, int64(n), 10)
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler.
+func (m *Int) UnmarshalJSON(data []byte) error {
+	var obj map[uint8]int
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	var m2 Int
+	for c, n := range obj {
+		m2[c] = n
+	}
+	*m = m2
+	return nil
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*Float)(nil)
+	_ encoding.BinaryUnmarshaler = (*Float)(nil)
+	_ encoding.TextMarshaler     = (*Float)(nil)
+	_ encoding.TextUnmarshaler   = (*Float)(nil)
+	_ json.Marshaler             = (*Float)(nil)
+	_ json.Unmarshaler           = (*Float)(nil)
+)
+
+// MarshalBinary satisfies encoding.BinaryMarshaler.
+// The encoding is the IEEE 754 bits of each value in order,
+// as 8 byte little endian integers.
+func (m *Float) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, Len*8)
+	for _, f := range m {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(f))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary satisfies encoding.BinaryUnmarshaler.
+func (m *Float) UnmarshalBinary(data []byte) error {
+	if len(data) != Len*8 {
+		return fmt.Errorf("invalid Float length: %d", len(data))
+	}
+	for i := range m {
+		m[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return nil
+}
+
+// MarshalText satisfies encoding.TextMarshaler.
+// The text form is a space separated list of byte:value pairs
+// for each byte with a non-zero value, such as "97:0.75 98:0.25".
+func (m *Float) MarshalText() ([]byte, error) {
+	var b []byte
+	for i, f := range m {
+		if math.Float64bits(f) == 0 {
+			continue
+		}
+		if len(b) > 0 {
+			b = append(b, ' ')
+		}
+		b = strconv.AppendInt(b, int64(i), 10)
+		b = append(b, ':')
+		b = strconv.AppendFloat(b, f, 'g', -1, 64)
+	}
+	return b, nil
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler.
+func (m *Float) UnmarshalText(text []byte) error {
+	var m2 Float
+	err := parsePairs(string(text), func(c byte, val string) error {
+		f, err := strconv.ParseFloat(val, 64)
+		m2[c] = f
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	*m = m2
+	return nil
+}
+
+// MarshalJSON satisfies json.Marshaler.
+// The JSON form is an object from byte to value
+// for each byte with a non-zero value, such as {"97":0.75,"98":0.25}.
+// It is an error to marshal a Float containing NaN or infinity.
+func (m *Float) MarshalJSON() ([]byte, error) {
+	b := []byte{'{'}
+	for i, f := range m {
+		if math.Float64bits(f) == 0 {
+			continue
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("invalid Float value for byte %d: %v", i, f)
+		}
+		if len(b) > 1 {
+			b = append(b, ',')
+		}
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(i), 10)
+		b = append(b, '"', ':')
+		b = strconv.AppendFloat(b, f, 'g', -1, 64)
+	}
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler.
+func (m *Float) UnmarshalJSON(data []byte) error {
+	var obj map[uint8]float64
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	var m2 Float
+	for c, f := range obj {
+		m2[c] = f
+	}
+	*m = m2
+	return nil
+}
+
+// parsePairs calls setVal for each byte:value pair in s.
+func parsePairs(s string, setVal func(c byte, val string) error) error {
+	for _, field := range strings.Fields(s) {
+		key, val, ok := strings.Cut(field, ":")
+		if !ok {
+			return fmt.Errorf("invalid pair: %q", field)
+		}
+		c, err := strconv.ParseUint(key, 10, 8)
+		if err != nil {
+			return fmt.Errorf("invalid pair: %q: %w", field, err)
+		}
+		if err = setVal(byte(c), val); err != nil {
+			return fmt.Errorf("invalid pair: %q: %w", field, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package bytemap_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+type marshaler interface {
+	encoding.BinaryMarshaler
+	encoding.TextMarshaler
+}
+
+type unmarshaler interface {
+	encoding.BinaryUnmarshaler
+	encoding.TextUnmarshaler
+}
+
+func testRoundTrip[T any, M interface {
+	*T
+	marshaler
+	unmarshaler
+	Equals(*T) bool
+}](t *testing.T, m M) {
+	t.Helper()
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2 := M(new(T))
+	if err = m2.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !m2.Equals((*T)(m)) {
+		t.Fatalf("binary round trip: %v != %v", m, m2)
+	}
+	b, err = m.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2 = M(new(T))
+	if err = m2.UnmarshalText(b); err != nil {
+		t.Fatalf("%q: %v", b, err)
+	}
+	if !m2.Equals((*T)(m)) {
+		t.Fatalf("text round trip %q: %v != %v", b, m, m2)
+	}
+	b, err = json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2 = M(new(T))
+	if err = json.Unmarshal(b, m2); err != nil {
+		t.Fatalf("%s: %v", b, err)
+	}
+	if !m2.Equals((*T)(m)) {
+		t.Fatalf("JSON round trip %s: %v != %v", b, m, m2)
+	}
+}
+
+func FuzzMarshal(f *testing.F) {
+	f.Add("")
+	f.Add("a")
+	f.Add("abc")
+	f.Add("\x00\xff-]^[\\")
+	f.Add("the quick brown fox jumps over the lazy dog")
+	f.Fuzz(func(t *testing.T, s string) {
+		t.Run("Bool", func(t *testing.T) {
+			testRoundTrip(t, bytemap.Make(s))
+			testRoundTrip(t, bytemap.Make(s).Invert())
+		})
+		t.Run("BitField", func(t *testing.T) {
+			testRoundTrip(t, bytemap.Make(s).ToBitField())
+			testRoundTrip(t, bytemap.Make(s).Invert().ToBitField())
+		})
+		t.Run("BitSet256", func(t *testing.T) {
+			testRoundTrip(t, bytemap.Make(s).ToBitSet256())
+			testRoundTrip(t, bytemap.Make(s).Invert().ToBitSet256())
+		})
+		t.Run("Int", func(t *testing.T) {
+			var m bytemap.Int
+			m.WriteString(s)
+			testRoundTrip(t, &m)
+			for i, c := range []byte(s) {
+				m[c] = -(i + 1) * math.MaxInt32
+			}
+			testRoundTrip(t, &m)
+		})
+		t.Run("Float", func(t *testing.T) {
+			var m bytemap.Float
+			m.WriteString(s)
+			testRoundTrip(t, &m)
+			if s != "" {
+				m.SetFrequencies()
+				testRoundTrip(t, &m)
+			}
+			for i, c := range []byte(s) {
+				m[c] = -float64(i+1) * 1e100
+			}
+			testRoundTrip(t, &m)
+		})
+	})
+}
+
+func TestMarshalText(t *testing.T) {
+	var i bytemap.Int
+	i.WriteString("abacus")
+	var f bytemap.Float
+	f.WriteString("aab")
+	f.SetFrequencies()
+	for _, tc := range []struct {
+		m    encoding.TextMarshaler
+		want string
+	}{
+		{&bytemap.Bool{}, `[^\x00-\xff]`},
+		{bytemap.Range(0, 255), `[\x00-\xff]`},
+		{bytemap.Make("a"), `[a]`},
+		{bytemap.Make("ab"), `[ab]`},
+		{bytemap.Make("abc"), `[a-c]`},
+		{bytemap.Make("abcxz"), `[a-cxz]`},
+		{bytemap.Make("-^]\\["), `[\-\[-\^]`},
+		{bytemap.Make("\x00\n\x7f\xff"), `[\x00\x0a\x7f\xff]`},
+		{bytemap.Make("a").Invert(), `[^a]`},
+		{bytemap.Range(0, 127), `[\x00-\x7f]`},
+		{bytemap.Range(0, 128), `[^\x81-\xff]`},
+		{bytemap.Make("a").ToBitField(), `[a]`},
+		{bytemap.Make("a").ToBitSet256(), `[a]`},
+		{&i, "97:2 98:1 99:1 115:1 117:1"},
+		{&bytemap.Int{}, ""},
+		{&f, "97:0.6666666666666666 98:0.3333333333333333"},
+		{&bytemap.Float{}, ""},
+	} {
+		b, err := tc.m.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tc.want {
+			t.Errorf("got %q; want %q", b, tc.want)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	var i bytemap.Int
+	i.WriteString("aab")
+	var f bytemap.Float
+	f.WriteString("abbb")
+	f.SetFrequencies()
+	v := struct {
+		Bool     *bytemap.Bool
+		BitField *bytemap.BitField
+		Int      *bytemap.Int
+		Float    *bytemap.Float
+	}{bytemap.Make("0123456789"), bytemap.Make("xyz").ToBitField(), &i, &f}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"Bool":"[0-9]","BitField":"[x-z]","Int":{"97":2,"98":1},"Float":{"97":0.25,"98":0.75}}`
+	if string(b) != want {
+		t.Fatalf("got %s", b)
+	}
+	f.Set(0, math.NaN())
+	if _, err = json.Marshal(&f); err == nil {
+		t.Fatal("NaN should not marshal")
+	}
+}
+
+func TestUnmarshalError(t *testing.T) {
+	for _, tc := range []struct {
+		m    unmarshaler
+		text string
+		bin  []byte
+	}{
+		{&bytemap.Bool{}, "[a", make([]byte, 31)},
+		{&bytemap.BitField{}, "a]", make([]byte, 33)},
+		{&bytemap.BitSet256{}, "[[:nope:]]", nil},
+		{&bytemap.Int{}, "97", make([]byte, 255)},
+		{&bytemap.Int{}, "256:1", make([]byte, 257)},
+		{&bytemap.Int{}, "97:x", []byte{0x80}},
+		{&bytemap.Float{}, "97:1:2", make([]byte, 8*256-1)},
+		{&bytemap.Float{}, "-1:1", nil},
+	} {
+		if err := tc.m.UnmarshalText([]byte(tc.text)); err == nil {
+			t.Errorf("%T: UnmarshalText(%q) should fail", tc.m, tc.text)
+		}
+		if err := tc.m.UnmarshalBinary(tc.bin); err == nil {
+			t.Errorf("%T: UnmarshalBinary(%d bytes) should fail", tc.m, len(tc.bin))
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package bytemap
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Pair is a map from pairs of adjacent bytes to counts.
+// It is 256 times larger than an Int,
+// so it should generally be allocated with new.
+//
+// Pair remembers the last byte written to it,
+// so a pair split across two writes is still counted.
+type Pair struct {
+	counts  [Len]Int
+	last    byte
+	hasLast bool
+}
+
+var _ io.Writer = (*Pair)(nil)
+
+// Write satisfies io.Writer.
+func (m *Pair) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	prev := p[0]
+	if m.hasLast {
+		m.counts[m.last][prev]++
+	}
+	for _, c := range p[1:] {
+		m.counts[prev][c]++
+		prev = c
+	}
+	m.last, m.hasLast = prev, true
+	return len(p), nil
+}
+
+var _ io.StringWriter = (*Pair)(nil)
+
+// WriteString satisfies io.StringWriter.
+func (m *Pair) WriteString(s string) (n int, err error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	prev := s[0]
+	if m.hasLast {
+		m.counts[m.last][prev]++
+	}
+	for _, c := range []byte(s[1:]) {
+		m.counts[prev][c]++
+		prev = c
+	}
+	m.last, m.hasLast = prev, true
+	return len(s), nil
+}
+
+// Break forgets the last byte written,
+// so the next write does not count a pair spanning the break.
+// Use it between unrelated streams.
+func (m *Pair) Break() {
+	m.hasLast = false
+}
+
+// Reset clears all counts and forgets the last byte written.
+func (m *Pair) Reset() {
+	*m = Pair{}
+}
+
+// Set sets the count for the pair of first followed by second.
+func (m *Pair) Set(first, second byte, value int) {
+	m.counts[first][second] = value
+}
+
+// Get looks up the count for the pair of first followed by second.
+func (m *Pair) Get(first, second byte) int {
+	return m.counts[first][second]
+}
+
+// Equals reports if two Pairs have the same counts.
+// It does not compare their last bytes written.
+func (m *Pair) Equals(other *Pair) bool {
+	return m.counts == other.counts
+}
+
+// Clone copies m.
+func (m *Pair) Clone() *Pair {
+	m2 := new(Pair)
+	*m2 = *m
+	return m2
+}
+
+// Row returns the counts of the bytes following first.
+func (m *Pair) Row(first byte) *Int {
+	return m.counts[first].Clone()
+}
+
+// Column returns the counts of the bytes preceding second.
+func (m *Pair) Column(second byte) *Int {
+	var m2 Int
+	for i := range m.counts {
+		m2[i] = m.counts[i][second]
+	}
+	return &m2
+}
+
+// RowMarginals returns the count of pairs starting with each byte.
+func (m *Pair) RowMarginals() *Int {
+	var m2 Int
+	for i := range m.counts {
+		for _, n := range m.counts[i] {
+			m2[i] += n
+		}
+	}
+	return &m2
+}
+
+// ColumnMarginals returns the count of pairs ending with each byte.
+func (m *Pair) ColumnMarginals() *Int {
+	var m2 Int
+	for i := range m.counts {
+		for j, n := range m.counts[i] {
+			m2[j] += n
+		}
+	}
+	return &m2
+}
+
+// Conditional returns the probability of each byte following first.
+// If first has never been followed by anything, all probabilities are 0.
+func (m *Pair) Conditional(first byte) *Float {
+	m2 := m.counts[first].ToFloat()
+	if total := m2.Sum(); total > 0 {
+		for i := range m2 {
+			m2[i] /= total
+		}
+	}
+	return m2
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*Pair)(nil)
+	_ encoding.BinaryUnmarshaler = (*Pair)(nil)
+	_ encoding.TextMarshaler     = (*Pair)(nil)
+	_ encoding.TextUnmarshaler   = (*Pair)(nil)
+)
+
+// MarshalBinary satisfies encoding.BinaryMarshaler.
+// The encoding is the number of non-zero counts as a uvarint
+// followed by the first byte, second byte, and signed varint count
+// of each pair with a non-zero count.
+// The last byte written is not encoded.
+func (m *Pair) MarshalBinary() ([]byte, error) {
+	n := 0
+	for i := range m.counts {
+		for _, v := range m.counts[i] {
+			if v != 0 {
+				n++
+			}
+		}
+	}
+	b := binary.AppendUvarint(nil, uint64(n))
+	for i := range m.counts {
+		for j, v := range m.counts[i] {
+			if v != 0 {
+				b = append(b, byte(i), byte(j))
+				b = binary.AppendVarint(b, int64(v))
+			}
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary satisfies encoding.BinaryUnmarshaler.
+func (m *Pair) UnmarshalBinary(data []byte) error {
+	n, size := binary.Uvarint(data)
+	if size <= 0 || n > Len*Len {
+		return fmt.Errorf("invalid Pair length")
+	}
+	data = data[size:]
+	m2 := new(Pair)
+	for range n {
+		if len(data) < 2 {
+			return fmt.Errorf("invalid Pair: truncated")
+		}
+		first, second := data[0], data[1]
+		v, size := binary.Varint(data[2:])
+		if size <= 0 || int64(int(v)) != v {
+			return fmt.Errorf("invalid Pair count for %d,%d", first, second)
+		}
+		m2.counts[first][second] = int(v)
+		data = data[2+size:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("invalid Pair: %d extra bytes", len(data))
+	}
+	*m = *m2
+	return nil
+}
+
+// MarshalText satisfies encoding.TextMarshaler.
+// The text form is a space separated list of first,second:count triples
+// for each pair with a non-zero count, such as "97,98:3 98,97:1".
+// The last byte written is not encoded.
+func (m *Pair) MarshalText() ([]byte, error) {
+	var b []byte
+	for i := range m.counts {
+		for j, v := range m.counts[i] {
+			if v == 0 {
+				continue
+			}
+			if len(b) > 0 {
+				b = append(b, ' ')
+			}
+			b = strconv.AppendInt(b, int64(i), 10)
+			b = append(b, ',')
+			b = strconv.AppendInt(b, int64(j), 10)
+			b = append(b, ':')
+			b = strconv.AppendInt(b, int64(v), 10)
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler.
+func (m *Pair) UnmarshalText(text []byte) error {
+	m2 := new(Pair)
+	for _, field := range strings.Fields(string(text)) {
+		key, val, ok := strings.Cut(field, ":")
+		if !ok {
+			return fmt.Errorf("invalid triple: %q", field)
+		}
+		key1, key2, ok := strings.Cut(key, ",")
+		if !ok {
+			return fmt.Errorf("invalid triple: %q", field)
+		}
+		first, err1 := strconv.ParseUint(key1, 10, 8)
+		second, err2 := strconv.ParseUint(key2, 10, 8)
+		v, err3 := strconv.Atoi(val)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return fmt.Errorf("invalid triple: %q", field)
+		}
+		m2.counts[first][second] = v
+	}
+	*m = *m2
+	return nil
+}
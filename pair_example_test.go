@@ -0,0 +1,31 @@
+//go:build goexperiment.rangefunc || go1.23
+
+package bytemap_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func ExamplePair_MostCommon() {
+	pairs := new(bytemap.Pair)
+	r := strings.NewReader("the cat sat on the mat")
+	_, _ = io.Copy(pairs, r)
+	for pair, n := range pairs.MostCommon() {
+		if n < 2 {
+			break
+		}
+		fmt.Printf("%q: %d\n", pair[:], n)
+	}
+	fmt.Printf("P('h' after 't') = %.2f\n", pairs.Conditional('t').Get('h'))
+	// Output:
+	// "at": 3
+	// "e ": 2
+	// "he": 2
+	// "t ": 2
+	// "th": 2
+	// P('h' after 't') = 0.50
+}
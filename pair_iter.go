@@ -0,0 +1,33 @@
+//go:build go1.23 || goexperiment.rangefunc
+
+package bytemap
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// MostCommon returns a sequence of the pairs in m with non-zero counts
+// and their counts, from highest count to lowest.
+// Each pair is a first byte followed by a second byte.
+func (m *Pair) MostCommon() iter.Seq2[[2]byte, int] {
+	return func(yield func([2]byte, int) bool) {
+		var pairs [][2]byte
+		for i := range m.counts {
+			for j, v := range m.counts[i] {
+				if v != 0 {
+					pairs = append(pairs, [2]byte{byte(i), byte(j)})
+				}
+			}
+		}
+		slices.SortStableFunc(pairs, func(a, b [2]byte) int {
+			return cmp.Compare(m.counts[b[0]][b[1]], m.counts[a[0]][a[1]])
+		})
+		for _, p := range pairs {
+			if !yield(p, m.counts[p[0]][p[1]]) {
+				return
+			}
+		}
+	}
+}
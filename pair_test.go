@@ -0,0 +1,155 @@
+package bytemap_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func naivePairs(s string) map[[2]byte]int {
+	m := make(map[[2]byte]int)
+	for i := 1; i < len(s); i++ {
+		m[[2]byte{s[i-1], s[i]}]++
+	}
+	return m
+}
+
+func testPairs(t *testing.T, m *bytemap.Pair, s string) {
+	t.Helper()
+	naive := naivePairs(s)
+	for i := range bytemap.Len {
+		for j := range bytemap.Len {
+			if got, want := m.Get(byte(i), byte(j)), naive[[2]byte{byte(i), byte(j)}]; got != want {
+				t.Fatalf("%q: Get(%q, %q) = %d; want %d", s, i, j, got, want)
+			}
+		}
+	}
+}
+
+func FuzzPair(f *testing.F) {
+	f.Add("")
+	f.Add("a")
+	f.Add("aa")
+	f.Add("hello, world")
+	f.Fuzz(func(t *testing.T, s string) {
+		t.Run("Write", func(t *testing.T) {
+			m := new(bytemap.Pair)
+			n, err := m.Write([]byte(s))
+			if err != nil || n != len(s) {
+				t.Fatal(n, err)
+			}
+			testPairs(t, m, s)
+		})
+		t.Run("WriteString", func(t *testing.T) {
+			m := new(bytemap.Pair)
+			half := len(s) / 2
+			m.WriteString(s[:half])
+			m.WriteString("")
+			m.WriteString(s[half:])
+			testPairs(t, m, s)
+		})
+		t.Run("Copy", func(t *testing.T) {
+			m := new(bytemap.Pair)
+			n, err := io.Copy(m, iotest.OneByteReader(strings.NewReader(s)))
+			if err != nil || n != int64(len(s)) {
+				t.Fatal(n, err)
+			}
+			testPairs(t, m, s)
+		})
+		t.Run("Marshal", func(t *testing.T) {
+			m := new(bytemap.Pair)
+			m.WriteString(s)
+			for i := range len(s) {
+				m.Set(s[i], 'x', -i*1000)
+			}
+			b, err := m.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			m2 := new(bytemap.Pair)
+			if err = m2.UnmarshalBinary(b); err != nil {
+				t.Fatal(err)
+			}
+			if !m2.Equals(m) {
+				t.Fatal("binary round trip")
+			}
+			b, err = m.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			m2 = new(bytemap.Pair)
+			if err = m2.UnmarshalText(b); err != nil {
+				t.Fatal(err)
+			}
+			if !m2.Equals(m) {
+				t.Fatalf("text round trip %q", b)
+			}
+		})
+	})
+}
+
+func TestPairBreak(t *testing.T) {
+	m := new(bytemap.Pair)
+	m.WriteString("ab")
+	m.Break()
+	m.WriteString("cd")
+	if m.Get('b', 'c') != 0 || m.Get('c', 'd') != 1 {
+		t.Fatal("wrong counts across break")
+	}
+	m.Set('c', 'd', 0)
+	testPairs(t, m, "ab")
+	m2 := m.Clone()
+	m.Reset()
+	testPairs(t, m, "")
+	testPairs(t, m2, "ab")
+	m.WriteString("b")
+	testPairs(t, m, "")
+}
+
+func TestPairMarginals(t *testing.T) {
+	const s = "abracadabra"
+	m := new(bytemap.Pair)
+	m.WriteString(s)
+	var rows, cols bytemap.Int
+	rows.WriteString(s[:len(s)-1])
+	cols.WriteString(s[1:])
+	if got := m.RowMarginals(); !got.Equals(&rows) {
+		t.Fatal(got.ToMap())
+	}
+	if got := m.ColumnMarginals(); !got.Equals(&cols) {
+		t.Fatal(got.ToMap())
+	}
+	var afterA, beforeA bytemap.Int
+	afterA.WriteString("bcdb")
+	beforeA.WriteString("rcdr")
+	if got := m.Row('a'); !got.Equals(&afterA) {
+		t.Fatal(got.ToMap())
+	}
+	if got := m.Column('a'); !got.Equals(&beforeA) {
+		t.Fatal(got.ToMap())
+	}
+	p := m.Conditional('a')
+	if p.Get('b') != 0.5 || p.Get('c') != 0.25 || p.Get('d') != 0.25 || p.Sum() != 1 {
+		t.Fatal(p.ToMap())
+	}
+	if p := m.Conditional('z'); p.Sum() != 0 {
+		t.Fatal(p.ToMap())
+	}
+}
+
+func TestPairUnmarshalError(t *testing.T) {
+	m := new(bytemap.Pair)
+	for _, text := range []string{"1", "1:2", "1,2", "256,1:1", "1,2:x"} {
+		if err := m.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) should fail", text)
+		}
+	}
+	for _, b := range [][]byte{nil, {1}, {1, 2}, {1, 2, 3, 4, 5}, {0, 1}, {0xff, 0xff, 0xff, 0xff, 0x0f}} {
+		if err := m.UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%v) should fail", b)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package rangecode
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+const (
+	// top is the smallest range before the coder shifts out a byte
+	top = 1 << 24
+	// headerLen is the number of bytes the decoder reads to start
+	headerLen = 5
+)
+
+var errClosed = errors.New("write to closed Encoder")
+
+// Encoder compresses the bytes written to it.
+// It is a range coder in the style of LZMA,
+// which handles carries by delaying runs of 0xFF bytes.
+type Encoder struct {
+	w         io.Writer
+	model     *AdaptiveModel
+	low       uint64
+	rng       uint32
+	cache     byte
+	cacheSize int64
+	buf       []byte
+	err       error
+}
+
+// NewEncoder returns an Encoder which writes to w
+// and updates model with each byte it encodes.
+// The caller must call Close to write the final bytes.
+func NewEncoder(w io.Writer, model *AdaptiveModel) *Encoder {
+	return &Encoder{
+		w:         w,
+		model:     model,
+		rng:       0xFFFF_FFFF,
+		cacheSize: 1,
+		buf:       make([]byte, 0, 4096),
+	}
+}
+
+var _ io.WriteCloser = (*Encoder)(nil)
+
+// Write satisfies io.Writer.
+func (e *Encoder) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	for i, c := range p {
+		low, freq := e.model.Range(c)
+		r := e.rng / uint32(e.model.Total())
+		e.low += uint64(low) * uint64(r)
+		e.rng = uint32(freq) * r
+		for e.rng < top {
+			e.rng <<= 8
+			e.shiftLow()
+		}
+		e.model.Update(c)
+		if len(e.buf) >= cap(e.buf)-8 {
+			if err := e.flush(); err != nil {
+				return i + 1, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// shiftLow moves the top byte of low out,
+// holding back 0xFF bytes until it is known
+// whether a carry will propagate into them.
+func (e *Encoder) shiftLow() {
+	if uint32(e.low) < 0xFF00_0000 || e.low>>32 != 0 {
+		carry := byte(e.low >> 32)
+		b := e.cache
+		for ; e.cacheSize > 0; e.cacheSize-- {
+			e.buf = append(e.buf, b+carry)
+			b = 0xFF
+		}
+		e.cache = byte(e.low >> 24)
+	}
+	e.cacheSize++
+	e.low = (e.low & 0x00FF_FFFF) << 8
+}
+
+func (e *Encoder) flush() error {
+	if len(e.buf) == 0 {
+		return nil
+	}
+	_, err := e.w.Write(e.buf)
+	e.buf = e.buf[:0]
+	if err != nil {
+		e.err = err
+	}
+	return err
+}
+
+// Close writes the final bytes of the encoding.
+// It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.err != nil {
+		if e.err == errClosed {
+			return nil
+		}
+		return e.err
+	}
+	for range headerLen {
+		e.shiftLow()
+	}
+	if err := e.flush(); err != nil {
+		return err
+	}
+	e.err = errClosed
+	return nil
+}
+
+// Decoder decompresses bytes written by an Encoder.
+type Decoder struct {
+	r     io.ByteReader
+	model *AdaptiveModel
+	n     int64
+	code  uint32
+	rng   uint32
+	start bool
+	err   error
+}
+
+// NewDecoder returns a Decoder which reads n bytes from r
+// and updates model with each byte it decodes.
+// The model must start in the same state as the Encoder's model did.
+func NewDecoder(r io.Reader, model *AdaptiveModel, n int64) *Decoder {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Decoder{r: br, model: model, n: n, rng: 0xFFFF_FFFF}
+}
+
+var _ io.Reader = (*Decoder)(nil)
+
+func (d *Decoder) next() error {
+	c, err := d.r.ReadByte()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	d.code = d.code<<8 | uint32(c)
+	return err
+}
+
+// Read satisfies io.Reader.
+func (d *Decoder) Read(p []byte) (int, error) {
+	if d.err == nil && !d.start && d.n > 0 {
+		d.start = true
+		for range headerLen {
+			if err := d.next(); err != nil {
+				d.err = err
+				break
+			}
+		}
+	}
+	for i := range p {
+		if d.err != nil {
+			return i, d.err
+		}
+		if d.n <= 0 {
+			return i, io.EOF
+		}
+		total := uint32(d.model.Total())
+		r := d.rng / total
+		c, low, freq := d.model.Find(int(min(d.code/r, total-1)))
+		d.code -= uint32(low) * r
+		d.rng = uint32(freq) * r
+		for d.rng < top && d.err == nil {
+			d.rng <<= 8
+			d.err = d.next()
+		}
+		d.model.Update(c)
+		p[i] = c
+		d.n--
+	}
+	return len(p), nil
+}
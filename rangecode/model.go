@@ -0,0 +1,117 @@
+// Package rangecode compresses bytes with a range coder
+// driven by an adaptive order-0 model of byte frequencies.
+package rangecode
+
+import (
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+const (
+	// MaxTotal is the largest total count an AdaptiveModel allows
+	// before halving its counts.
+	MaxTotal = 1 << 16
+	// increment is how much a count grows each time its byte is seen.
+	// Growing by more than 1 lets the model adapt quickly
+	// from its uniform starting point.
+	increment = 32
+)
+
+// AdaptiveModel tracks byte frequencies for a range coder.
+// Every byte always has a count of at least 1,
+// so every byte can be encoded.
+//
+// The encoder and decoder must each start with a model in the same state.
+type AdaptiveModel struct {
+	counts bytemap.Int
+	// tree is a Fenwick tree of counts for cumulative frequency lookups.
+	// It is 1-indexed, so tree[i] covers counts ending at byte i-1.
+	tree  [bytemap.Len + 1]int
+	total int
+}
+
+// NewAdaptiveModel returns a model starting with the counts in prior.
+// Counts less than 1 are raised to 1,
+// and counts are halved until their total is at most MaxTotal.
+// If prior is nil, every byte starts with a count of 1.
+func NewAdaptiveModel(prior *bytemap.Int) *AdaptiveModel {
+	m := new(AdaptiveModel)
+	for i := range m.counts {
+		m.counts[i] = 1
+		if prior != nil {
+			m.counts[i] = max(prior[i], 1)
+		}
+	}
+	m.rebuild()
+	for m.total > MaxTotal {
+		m.rescale()
+	}
+	return m
+}
+
+// rebuild recomputes the tree and total from the counts.
+func (m *AdaptiveModel) rebuild() {
+	m.total = 0
+	for i, n := range m.counts {
+		m.tree[i+1] = n
+		m.total += n
+	}
+	for i := 1; i <= bytemap.Len; i++ {
+		if j := i + i&-i; j <= bytemap.Len {
+			m.tree[j] += m.tree[i]
+		}
+	}
+}
+
+// rescale halves the counts, keeping them at least 1.
+func (m *AdaptiveModel) rescale() {
+	for i, n := range m.counts {
+		m.counts[i] = (n + 1) / 2
+	}
+	m.rebuild()
+}
+
+// Counts returns a copy of the current counts.
+func (m *AdaptiveModel) Counts() *bytemap.Int {
+	return m.counts.Clone()
+}
+
+// Total returns the sum of the current counts.
+func (m *AdaptiveModel) Total() int {
+	return m.total
+}
+
+// Range returns the sum of the counts of the bytes less than c
+// and the count of c.
+func (m *AdaptiveModel) Range(c byte) (low, freq int) {
+	for i := int(c); i > 0; i -= i & -i {
+		low += m.tree[i]
+	}
+	return low, m.counts[c]
+}
+
+// Find returns the byte whose range contains target,
+// which must be less than Total, along with its range.
+func (m *AdaptiveModel) Find(target int) (c byte, low, freq int) {
+	pos := 0
+	for step := bytemap.Len; step > 0; step >>= 1 {
+		if next := pos + step; next <= bytemap.Len && m.tree[next] <= target {
+			pos = next
+			target -= m.tree[next]
+			low += m.tree[next]
+		}
+	}
+	return byte(pos), low, m.counts[pos]
+}
+
+// Update records an occurrence of c,
+// halving all counts if the total grows past MaxTotal.
+func (m *AdaptiveModel) Update(c byte) {
+	m.counts[c] += increment
+	m.total += increment
+	for i := int(c) + 1; i <= bytemap.Len; i += i & -i {
+		m.tree[i] += increment
+	}
+	if m.total > MaxTotal {
+		m.rescale()
+	}
+}
@@ -0,0 +1,27 @@
+package rangecode_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/earthboundkid/bytemap/v2/rangecode"
+)
+
+func Example() {
+	text := strings.Repeat("GET /index.html 200\n", 100)
+
+	var buf bytes.Buffer
+	enc := rangecode.NewEncoder(&buf, rangecode.NewAdaptiveModel(nil))
+	_, _ = io.WriteString(enc, text)
+	_ = enc.Close()
+	fmt.Println(len(text), "bytes compressed to", buf.Len())
+
+	dec := rangecode.NewDecoder(&buf, rangecode.NewAdaptiveModel(nil), int64(len(text)))
+	decoded, _ := io.ReadAll(dec)
+	fmt.Println(string(decoded) == text)
+	// Output:
+	// 2000 bytes compressed to 1059
+	// true
+}
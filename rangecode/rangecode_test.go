@@ -0,0 +1,133 @@
+package rangecode_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"testing/iotest"
+
+	"github.com/earthboundkid/bytemap/v2"
+	"github.com/earthboundkid/bytemap/v2/rangecode"
+)
+
+func roundTrip(t *testing.T, prior *bytemap.Int, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := rangecode.NewEncoder(&buf, rangecode.NewAdaptiveModel(prior))
+	if n, err := enc.Write(data); err != nil || n != len(data) {
+		t.Fatal(n, err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	encoded := bytes.Clone(buf.Bytes())
+	dec := rangecode.NewDecoder(iotest.HalfReader(&buf), rangecode.NewAdaptiveModel(prior), int64(len(data)))
+	got, err := io.ReadAll(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("round trip failed for %d bytes", len(data))
+	}
+	return encoded
+}
+
+func TestMobyDick(t *testing.T) {
+	data, err := os.ReadFile("../testdata/moby-dick.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded := roundTrip(t, nil, data)
+	var counts bytemap.Int
+	counts.Write(data)
+	// An adaptive model should come close to the order-0 entropy
+	bound := counts.Entropy() * float64(len(data)) / 8
+	if got := float64(len(encoded)); got > bound*1.02 {
+		t.Errorf("encoded %d bytes; order-0 entropy is %.0f bytes", len(encoded), bound)
+	}
+	// Starting from the right distribution helps a little
+	primed := roundTrip(t, &counts, data)
+	if len(primed) > len(encoded) {
+		t.Errorf("primed model encoded %d bytes; unprimed %d", len(primed), len(encoded))
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add([]byte(""))
+	f.Add([]byte("a"))
+	f.Add([]byte("\xff\xff\xff\xff\xff\xff\xff\xff"))
+	f.Add(bytes.Repeat([]byte("\x00"), 100_000))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		roundTrip(t, nil, data)
+	})
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	data := make([]byte, 100_000)
+	for i := range data {
+		data[i] = byte(r.Uint32())
+	}
+	encoded := roundTrip(t, nil, data)
+	// Random data cannot be compressed, but should not grow much either
+	if len(encoded) > len(data)+len(data)/50 {
+		t.Errorf("encoded %d bytes from %d", len(encoded), len(data))
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	enc := rangecode.NewEncoder(&buf, rangecode.NewAdaptiveModel(nil))
+	enc.Write([]byte("hello, world"))
+	enc.Close()
+	if _, err := enc.Write([]byte("x")); err == nil {
+		t.Fatal("write after Close should fail")
+	}
+	truncated := buf.Bytes()[:buf.Len()/2]
+	dec := rangecode.NewDecoder(bytes.NewReader(truncated), rangecode.NewAdaptiveModel(nil), 12)
+	if _, err := io.ReadAll(dec); err != io.ErrUnexpectedEOF {
+		t.Fatal(err)
+	}
+}
+
+func TestModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	m := rangecode.NewAdaptiveModel(nil)
+	for i := range 10_000 {
+		m.Update(byte(r.IntN(1 + i%256)))
+		if i%97 != 0 {
+			continue
+		}
+		counts := m.Counts()
+		total, _ := counts.Sum()
+		if m.Total() != total || total > rangecode.MaxTotal {
+			t.Fatalf("Total() = %d; counts sum to %d", m.Total(), total)
+		}
+		low := 0
+		for c, n := range counts {
+			if n < 1 {
+				t.Fatalf("count for %d is %d", c, n)
+			}
+			gotLow, gotFreq := m.Range(byte(c))
+			if gotLow != low || gotFreq != n {
+				t.Fatalf("Range(%d) = %d, %d; want %d, %d", c, gotLow, gotFreq, low, n)
+			}
+			for _, target := range []int{low, low + n - 1} {
+				gotC, gotLow, gotFreq := m.Find(target)
+				if int(gotC) != c || gotLow != low || gotFreq != n {
+					t.Fatalf("Find(%d) = %d, %d, %d; want %d, %d, %d",
+						target, gotC, gotLow, gotFreq, c, low, n)
+				}
+			}
+			low += n
+		}
+	}
+	var prior bytemap.Int
+	prior['a'] = 1 << 20
+	m = rangecode.NewAdaptiveModel(&prior)
+	if m.Total() > rangecode.MaxTotal || m.Counts().Get('b') != 1 {
+		t.Fatal(m.Total(), m.Counts().Get('b'))
+	}
+}
@@ -0,0 +1,168 @@
+package bytemap
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+)
+
+// ErrNoWeights is returned when creating a Sampler
+// from a map with no positive values.
+var ErrNoWeights = errors.New("no positive values to sample from")
+
+func cumulative[V int | float64](m *[Len]V) [Len]V {
+	var sums [Len]V
+	var sum V
+	for i, v := range m {
+		sum += v
+		sums[i] = sum
+	}
+	return sums
+}
+
+// Cumulative returns the running totals of the counts in m,
+// so that the value for each byte is the sum of the counts
+// for it and all smaller bytes.
+func (m *Int) Cumulative() *Int {
+	m2 := Int(cumulative((*[Len]int)(m)))
+	return &m2
+}
+
+// Quantile returns the smallest byte
+// such that at least the fraction p of the counts in m
+// are for it or smaller bytes.
+// It is like Percentile with p from 0 to 1 instead of 0 to 100.
+// If no byte has a positive count, it returns 0.
+func (m *Int) Quantile(p float64) byte {
+	c, _ := m.Percentile(p * 100)
+	return c
+}
+
+// Cumulative returns the running totals of the values in m,
+// so that the value for each byte is the sum of the values
+// for it and all smaller bytes.
+func (m *Float) Cumulative() *Float {
+	m2 := Float(cumulative((*[Len]float64)(m)))
+	return &m2
+}
+
+// Quantile returns the smallest byte
+// such that at least the fraction p of the values in m
+// are for it or smaller bytes.
+// It is like Percentile with p from 0 to 1 instead of 0 to 100.
+// If no byte has a positive value, it returns 0.
+func (m *Float) Quantile(p float64) byte {
+	c, _ := m.Percentile(p * 100)
+	return c
+}
+
+// Sampler draws random bytes from a fixed distribution
+// in constant time using Vose's alias method.
+// It is not safe for concurrent use.
+type Sampler struct {
+	// Each byte i is drawn with probability prob[i]
+	// and otherwise replaced by alias[i].
+	prob  [Len]float64
+	alias [Len]byte
+	rng   *rand.Rand
+}
+
+func newSampler(p *[Len]float64, src rand.Source) *Sampler {
+	if src == nil {
+		src = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+	s := &Sampler{rng: rand.New(src)}
+	var scaled [Len]float64
+	var small, large []byte
+	var most byte
+	for i, pi := range p {
+		if pi > p[most] {
+			most = byte(i)
+		}
+		scaled[i] = pi * Len
+		if scaled[i] < 1 {
+			small = append(small, byte(i))
+		} else {
+			large = append(large, byte(i))
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		l, g := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		s.prob[l], s.alias[l] = scaled[l], g
+		scaled[g] -= 1 - scaled[l]
+		if scaled[g] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, g)
+		}
+	}
+	// Whatever is left over is 1 but for rounding error
+	for _, i := range large {
+		s.prob[i] = 1
+	}
+	for _, i := range small {
+		s.prob[i], s.alias[i] = 1, most
+		// Never draw an impossible byte
+		if p[i] == 0 {
+			s.prob[i] = 0
+		}
+	}
+	return s
+}
+
+// Sampler returns a Sampler which draws bytes
+// in proportion to their counts in m, using src for randomness.
+// Bytes with zero or negative counts are never drawn.
+// If src is nil, it uses a randomly seeded source.
+// If no byte has a positive count, it returns ErrNoWeights.
+func (m *Int) Sampler(src rand.Source) (*Sampler, error) {
+	p, ok := probabilities((*[Len]int)(m))
+	if !ok {
+		return nil, ErrNoWeights
+	}
+	return newSampler(&p, src), nil
+}
+
+// Sampler returns a Sampler which draws bytes
+// in proportion to their values in m, using src for randomness.
+// Bytes with zero, negative, or NaN values are never drawn.
+// If src is nil, it uses a randomly seeded source.
+// If no byte has a positive value or the values sum to infinity,
+// it returns ErrNoWeights.
+func (m *Float) Sampler(src rand.Source) (*Sampler, error) {
+	p, ok := probabilities((*[Len]float64)(m))
+	if !ok {
+		return nil, ErrNoWeights
+	}
+	return newSampler(&p, src), nil
+}
+
+// Byte returns a random byte.
+func (s *Sampler) Byte() byte {
+	// Use the top 8 bits to pick a column
+	// and the low 53 bits to choose between it and its alias.
+	x := s.rng.Uint64()
+	i := byte(x >> 56)
+	if float64(x&(1<<53-1))/(1<<53) < s.prob[i] {
+		return i
+	}
+	return s.alias[i]
+}
+
+// Generate returns n random bytes.
+func (s *Sampler) Generate(n int) []byte {
+	b := make([]byte, n)
+	_, _ = s.Read(b)
+	return b
+}
+
+var _ io.Reader = (*Sampler)(nil)
+
+// Read satisfies io.Reader.
+// It fills p with random bytes and never returns an error.
+func (s *Sampler) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = s.Byte()
+	}
+	return len(p), nil
+}
@@ -0,0 +1,100 @@
+package bytemap_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestCumulative(t *testing.T) {
+	var m bytemap.Int
+	m.WriteString("abbccc")
+	c := m.Cumulative()
+	if c.Get('a'-1) != 0 || c.Get('a') != 1 || c.Get('b') != 3 || c.Get('c') != 6 || c.Get(255) != 6 {
+		t.Fatal(c.ToMap())
+	}
+	f := m.ToFloat().Cumulative()
+	for i := range bytemap.Len {
+		if f[i] != float64(c[i]) {
+			t.Fatal(f.ToMap())
+		}
+	}
+	for _, tc := range []struct {
+		p    float64
+		want byte
+	}{
+		{-1, 'a'}, {0, 'a'}, {1.0 / 6, 'a'}, {0.2, 'b'}, {0.5, 'b'}, {0.51, 'c'}, {1, 'c'}, {2, 'c'},
+	} {
+		if got := m.Quantile(tc.p); got != tc.want {
+			t.Errorf("Quantile(%v) = %q; want %q", tc.p, got, tc.want)
+		}
+		if got := m.ToFloat().Quantile(tc.p); got != tc.want {
+			t.Errorf("Float.Quantile(%v) = %q; want %q", tc.p, got, tc.want)
+		}
+	}
+	if got := new(bytemap.Int).Quantile(0.5); got != 0 {
+		t.Fatal(got)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	data, err := os.ReadFile("testdata/moby-dick.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected bytemap.Int
+	expected.Write(data)
+	s, err := expected.Sampler(rand.NewPCG(1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var observed bytemap.Int
+	observed.Write(s.Generate(100_000))
+	if !observed.ToBool().ToBitField().IsSubsetOf(expected.ToBool().ToBitField()) {
+		t.Fatal("sampled a byte with no count")
+	}
+	if _, p := observed.ChiSquared(&expected); p < 0.001 {
+		t.Fatalf("sample does not match distribution: p = %v", p)
+	}
+
+	// The same source gives the same bytes
+	s1, _ := expected.ToFloat().Sampler(rand.NewPCG(3, 4))
+	s2, _ := expected.ToFloat().Sampler(rand.NewPCG(3, 4))
+	b1 := s1.Generate(100)
+	b2 := make([]byte, 100)
+	if _, err := io.ReadFull(s2, b2); err != nil || !bytes.Equal(b1, b2) {
+		t.Fatal(err, b1, b2)
+	}
+}
+
+func TestSamplerEdgeCases(t *testing.T) {
+	var m bytemap.Int
+	if _, err := m.Sampler(nil); err != bytemap.ErrNoWeights {
+		t.Fatal(err)
+	}
+	m['x'] = 5
+	m['y'] = -5
+	s, err := m.Sampler(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Generate(1000); !bytes.Equal(got, bytes.Repeat([]byte("x"), 1000)) {
+		t.Fatalf("%q", got)
+	}
+	var f bytemap.Float
+	f['a'] = 1e-300
+	f['b'] = 1e300
+	s, err = f.Sampler(rand.NewPCG(5, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range s.Generate(1000) {
+		if c != 'a' && c != 'b' {
+			t.Fatalf("sampled %q", c)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package bytemap
+
+type byteseq interface {
+	[]byte | string
+}
+
+// clip limits the capacity of each slice in a
+// so that appending to one can't overwrite the next.
+func clip(a [][]byte) [][]byte {
+	for i, b := range a {
+		a[i] = b[:len(b):len(b)]
+	}
+	return a
+}
+
+// indexFunc returns the index of the first byte of s
+// for which match returns true, or -1 if there is none.
+// It is small enough to be inlined,
+// so passing it a function literal costs no more than a loop.
+func indexFunc[S byteseq](s S, match func(byte) bool) int {
+	for i := 0; i < len(s); i++ {
+		if match(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastIndexFunc returns the index of the last byte of s
+// for which match returns true, or -1 if there is none.
+func lastIndexFunc[S byteseq](s S, match func(byte) bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if match(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// countFunc returns the number of bytes of s
+// for which match returns true.
+func countFunc[S byteseq](s S, match func(byte) bool) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if match(s[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+func trimLeft[S byteseq](s S, indexNotIn func(S) int) S {
+	i := indexNotIn(s)
+	if i == -1 {
+		return s[len(s):]
+	}
+	return s[i:]
+}
+
+func trimRight[S byteseq](s S, lastIndexNotIn func(S) int) S {
+	return s[:lastIndexNotIn(s)+1]
+}
+
+func split[S byteseq](s S, indexAny func(S) int) []S {
+	var a []S
+	for {
+		i := indexAny(s)
+		if i == -1 {
+			break
+		}
+		a = append(a, s[:i])
+		s = s[i+1:]
+	}
+	return append(a, s)
+}
+
+func fields[S byteseq](s S, indexAny, indexNotIn func(S) int) []S {
+	var a []S
+	for {
+		start := indexNotIn(s)
+		if start == -1 {
+			return a
+		}
+		s = s[start:]
+		end := indexAny(s)
+		if end == -1 {
+			return append(a, s)
+		}
+		a = append(a, s[:end])
+		s = s[end+1:]
+	}
+}
+
+func cut[S byteseq](s S, indexAny func(S) int) (before, after S, found bool) {
+	if i := indexAny(s); i != -1 {
+		return s[:i], s[i+1:], true
+	}
+	return s, s[len(s):], false
+}
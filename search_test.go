@@ -0,0 +1,209 @@
+package bytemap_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+type Searcher interface {
+	IndexAny(string) int
+	IndexAnyBytes([]byte) int
+	LastIndexAny(string) int
+	LastIndexAnyBytes([]byte) int
+	IndexNotIn(string) int
+	IndexNotInBytes([]byte) int
+	LastIndexNotIn(string) int
+	LastIndexNotInBytes([]byte) int
+	Count(string) int
+	CountBytes([]byte) int
+	TrimLeft(string) string
+	TrimLeftBytes([]byte) []byte
+	TrimRight(string) string
+	TrimRightBytes([]byte) []byte
+	Trim(string) string
+	TrimBytes([]byte) []byte
+	Split(string) []string
+	SplitBytes([]byte) [][]byte
+	Fields(string) []string
+	FieldsBytes([]byte) [][]byte
+	Cut(string) (string, string, bool)
+	CutBytes([]byte) ([]byte, []byte, bool)
+}
+
+func naiveSplit(s, charset string) []string {
+	m := naiveMap(charset)
+	var a []string
+	start := 0
+	for i := range len(s) {
+		if m[s[i]] {
+			a = append(a, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(a, s[start:])
+}
+
+func testSearch(t *testing.T, m Searcher, s, charset string) {
+	naive := naiveMap(charset)
+	in := func(c byte) bool { return naive[c] }
+	notIn := func(c byte) bool { return !naive[c] }
+	b := []byte(s)
+	check := func(name string, got, want any) {
+		t.Helper()
+		switch got := got.(type) {
+		case []string:
+			if !slices.Equal(got, want.([]string)) {
+				t.Fatalf("%s(%q) charset=%q got=%q want=%q",
+					name, s, charset, got, want)
+			}
+		case [][]byte:
+			want := want.([]string)
+			if !slices.EqualFunc(got, want, func(b []byte, s string) bool {
+				return string(b) == s
+			}) {
+				t.Fatalf("%s(%q) charset=%q got=%q want=%q",
+					name, s, charset, got, want)
+			}
+		case []byte:
+			if string(got) != want.(string) {
+				t.Fatalf("%s(%q) charset=%q got=%q want=%q",
+					name, s, charset, got, want)
+			}
+		default:
+			if got != want {
+				t.Fatalf("%s(%q) charset=%q got=%v want=%v",
+					name, s, charset, got, want)
+			}
+		}
+	}
+	indexOf := func(f func(byte) bool) int {
+		for i := range len(s) {
+			if f(s[i]) {
+				return i
+			}
+		}
+		return -1
+	}
+	lastIndexOf := func(f func(byte) bool) int {
+		for i := len(s) - 1; i >= 0; i-- {
+			if f(s[i]) {
+				return i
+			}
+		}
+		return -1
+	}
+	check("IndexAny", m.IndexAny(s), indexOf(in))
+	check("IndexAnyBytes", m.IndexAnyBytes(b), indexOf(in))
+	check("LastIndexAny", m.LastIndexAny(s), lastIndexOf(in))
+	check("LastIndexAnyBytes", m.LastIndexAnyBytes(b), lastIndexOf(in))
+	check("IndexNotIn", m.IndexNotIn(s), indexOf(notIn))
+	check("IndexNotInBytes", m.IndexNotInBytes(b), indexOf(notIn))
+	check("LastIndexNotIn", m.LastIndexNotIn(s), lastIndexOf(notIn))
+	check("LastIndexNotInBytes", m.LastIndexNotInBytes(b), lastIndexOf(notIn))
+
+	count := 0
+	for i := range len(s) {
+		if in(s[i]) {
+			count++
+		}
+	}
+	check("Count", m.Count(s), count)
+	check("CountBytes", m.CountBytes(b), count)
+
+	left := s
+	for len(left) > 0 && in(left[0]) {
+		left = left[1:]
+	}
+	right := s
+	for len(right) > 0 && in(right[len(right)-1]) {
+		right = right[:len(right)-1]
+	}
+	both := left
+	for len(both) > 0 && in(both[len(both)-1]) {
+		both = both[:len(both)-1]
+	}
+	check("TrimLeft", m.TrimLeft(s), left)
+	check("TrimLeftBytes", m.TrimLeftBytes(b), left)
+	check("TrimRight", m.TrimRight(s), right)
+	check("TrimRightBytes", m.TrimRightBytes(b), right)
+	check("Trim", m.Trim(s), both)
+	check("TrimBytes", m.TrimBytes(b), both)
+
+	parts := naiveSplit(s, charset)
+	check("Split", m.Split(s), parts)
+	check("SplitBytes", m.SplitBytes(b), parts)
+	var nonempty []string
+	for _, part := range parts {
+		if part != "" {
+			nonempty = append(nonempty, part)
+		}
+	}
+	check("Fields", m.Fields(s), nonempty)
+	check("FieldsBytes", m.FieldsBytes(b), nonempty)
+
+	before, after, found := s, "", false
+	if i := indexOf(in); i != -1 {
+		before, after, found = s[:i], s[i+1:], true
+	}
+	gotBefore, gotAfter, gotFound := m.Cut(s)
+	check("Cut", [3]any{gotBefore, gotAfter, gotFound}, [3]any{before, after, found})
+	bBefore, bAfter, bFound := m.CutBytes(b)
+	check("CutBytes", [3]any{string(bBefore), string(bAfter), bFound},
+		[3]any{before, after, found})
+}
+
+func FuzzSearch(f *testing.F) {
+	f.Add("", "")
+	f.Add("a", "a")
+	f.Add("  hello,  world ", " ,")
+	f.Add(",a,,b,", ",")
+	f.Add("key=value", "=")
+	f.Fuzz(func(t *testing.T, s, charset string) {
+		t.Run("Bool", func(t *testing.T) {
+			testSearch(t, bytemap.Make(charset), s, charset)
+		})
+		t.Run("BitField", func(t *testing.T) {
+			testSearch(t, bytemap.Make(charset).ToBitField(), s, charset)
+		})
+		t.Run("BitSet256", func(t *testing.T) {
+			testSearch(t, bytemap.Make(charset).ToBitSet256(), s, charset)
+		})
+	})
+}
+
+func TestSearchMatchesStrings(t *testing.T) {
+	space := bytemap.Make(" \t\n\v\f\r")
+	s := " \tthe quick  brown\nfox\r\n"
+	if got, want := space.Fields(s), strings.Fields(s); !slices.Equal(got, want) {
+		t.Fatal(got, want)
+	}
+	if got, want := space.Trim(s), strings.TrimSpace(s); got != want {
+		t.Fatal(got, want)
+	}
+	comma := bytemap.Make(",")
+	s = ",a,,b,"
+	if got, want := comma.Split(s), strings.Split(s, ","); !slices.Equal(got, want) {
+		t.Fatal(got, want)
+	}
+	if got := comma.Split(""); !slices.Equal(got, []string{""}) {
+		t.Fatal(got)
+	}
+}
+
+func TestSplitBytesCapacity(t *testing.T) {
+	comma := bytemap.Make(",").ToBitField()
+	b := []byte("a,b,c")
+	parts := comma.SplitBytes(b)
+	parts[0] = append(parts[0], 'x')
+	if string(b) != "a,b,c" {
+		t.Fatal(string(b))
+	}
+	before, _, _ := comma.CutBytes(b)
+	_ = append(before, 'x')
+	if string(b) != "a,b,c" {
+		t.Fatal(string(b))
+	}
+}
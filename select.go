@@ -0,0 +1,147 @@
+package bytemap
+
+import (
+	"cmp"
+	"slices"
+)
+
+func where[V int | float64](m *[Len]V, f func(byte, V) bool) *Bool {
+	var m2 Bool
+	for i, v := range m {
+		m2[i] = f(byte(i), v)
+	}
+	return &m2
+}
+
+func whereBitField[V int | float64](m *[Len]V, f func(byte, V) bool) *BitField {
+	var m2 BitField
+	for i, v := range m {
+		if f(byte(i), v) {
+			m2[i/8] |= 1 << (i % 8)
+		}
+	}
+	return &m2
+}
+
+func topK[V int | float64](m *[Len]V, k int) *Bool {
+	var bytes []byte
+	for i, v := range m {
+		if v > 0 {
+			bytes = append(bytes, byte(i))
+		}
+	}
+	slices.SortStableFunc(bytes, func(a, b byte) int {
+		return cmp.Compare(m[b], m[a])
+	})
+	var m2 Bool
+	for _, c := range bytes[:min(max(k, 0), len(bytes))] {
+		m2[c] = true
+	}
+	return &m2
+}
+
+// Where returns a Bool of the bytes for which f returns true
+// when called with the byte and its count in m.
+func (m *Int) Where(f func(byte, int) bool) *Bool {
+	return where((*[Len]int)(m), f)
+}
+
+// WhereBitField is like Where but returns a BitField.
+func (m *Int) WhereBitField(f func(byte, int) bool) *BitField {
+	return whereBitField((*[Len]int)(m), f)
+}
+
+// Above returns a Bool of the bytes with counts greater than threshold.
+func (m *Int) Above(threshold int) *Bool {
+	return m.Where(func(_ byte, v int) bool { return v > threshold })
+}
+
+// Below returns a Bool of the bytes with counts less than threshold.
+func (m *Int) Below(threshold int) *Bool {
+	return m.Where(func(_ byte, v int) bool { return v < threshold })
+}
+
+// Between returns a Bool of the bytes with counts from lo to hi inclusive.
+func (m *Int) Between(lo, hi int) *Bool {
+	return m.Where(func(_ byte, v int) bool { return lo <= v && v <= hi })
+}
+
+// TopK returns a Bool of the k bytes with the highest positive counts.
+// Ties are broken in favor of lower bytes.
+// If fewer than k bytes have positive counts, it returns all of them.
+func (m *Int) TopK(k int) *Bool {
+	return topK((*[Len]int)(m), k)
+}
+
+// AboveBitField is like Above but returns a BitField.
+func (m *Int) AboveBitField(threshold int) *BitField {
+	return m.WhereBitField(func(_ byte, v int) bool { return v > threshold })
+}
+
+// BelowBitField is like Below but returns a BitField.
+func (m *Int) BelowBitField(threshold int) *BitField {
+	return m.WhereBitField(func(_ byte, v int) bool { return v < threshold })
+}
+
+// BetweenBitField is like Between but returns a BitField.
+func (m *Int) BetweenBitField(lo, hi int) *BitField {
+	return m.WhereBitField(func(_ byte, v int) bool { return lo <= v && v <= hi })
+}
+
+// TopKBitField is like TopK but returns a BitField.
+func (m *Int) TopKBitField(k int) *BitField {
+	return m.TopK(k).ToBitField()
+}
+
+// Where returns a Bool of the bytes for which f returns true
+// when called with the byte and its value in m.
+func (m *Float) Where(f func(byte, float64) bool) *Bool {
+	return where((*[Len]float64)(m), f)
+}
+
+// WhereBitField is like Where but returns a BitField.
+func (m *Float) WhereBitField(f func(byte, float64) bool) *BitField {
+	return whereBitField((*[Len]float64)(m), f)
+}
+
+// Above returns a Bool of the bytes with values greater than threshold.
+func (m *Float) Above(threshold float64) *Bool {
+	return m.Where(func(_ byte, v float64) bool { return v > threshold })
+}
+
+// Below returns a Bool of the bytes with values less than threshold.
+func (m *Float) Below(threshold float64) *Bool {
+	return m.Where(func(_ byte, v float64) bool { return v < threshold })
+}
+
+// Between returns a Bool of the bytes with values from lo to hi inclusive.
+func (m *Float) Between(lo, hi float64) *Bool {
+	return m.Where(func(_ byte, v float64) bool { return lo <= v && v <= hi })
+}
+
+// TopK returns a Bool of the k bytes with the highest positive values.
+// Ties are broken in favor of lower bytes.
+// If fewer than k bytes have positive values, it returns all of them.
+func (m *Float) TopK(k int) *Bool {
+	return topK((*[Len]float64)(m), k)
+}
+
+// AboveBitField is like Above but returns a BitField.
+func (m *Float) AboveBitField(threshold float64) *BitField {
+	return m.WhereBitField(func(_ byte, v float64) bool { return v > threshold })
+}
+
+// BelowBitField is like Below but returns a BitField.
+func (m *Float) BelowBitField(threshold float64) *BitField {
+	return m.WhereBitField(func(_ byte, v float64) bool { return v < threshold })
+}
+
+// BetweenBitField is like Between but returns a BitField.
+func (m *Float) BetweenBitField(lo, hi float64) *BitField {
+	return m.WhereBitField(func(_ byte, v float64) bool { return lo <= v && v <= hi })
+}
+
+// TopKBitField is like TopK but returns a BitField.
+func (m *Float) TopKBitField(k int) *BitField {
+	return m.TopK(k).ToBitField()
+}
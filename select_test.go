@@ -0,0 +1,96 @@
+package bytemap_test
+
+import (
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestIntSelect(t *testing.T) {
+	var m bytemap.Int
+	m.WriteString("aaaabbbccd")
+	m.Set('z', -1)
+	for _, tc := range []struct {
+		name string
+		got  *bytemap.Bool
+		want string
+	}{
+		{"Above(2)", m.Above(2), "ab"},
+		{"Above(0)", m.Above(0), "abcd"},
+		{"Below(0)", m.Below(0), "z"},
+		{"Between(2, 3)", m.Between(2, 3), "bc"},
+		{"TopK(0)", m.TopK(0), ""},
+		{"TopK(-1)", m.TopK(-1), ""},
+		{"TopK(2)", m.TopK(2), "ab"},
+		{"TopK(10)", m.TopK(10), "abcd"},
+		{"Where", m.Where(func(c byte, v int) bool { return c > 'b' && v != 0 }), "cdz"},
+	} {
+		if want := bytemap.Make(tc.want); !tc.got.Equals(want) {
+			t.Errorf("%s = %q; want %q", tc.name, tc.got.String(), tc.want)
+		}
+	}
+	var n bytemap.Int
+	n.WriteString("abc")
+	if got := n.TopK(2); !got.Equals(bytemap.Make("ab")) {
+		t.Errorf("TopK ties = %q", got.String())
+	}
+	bf := m.WhereBitField(func(_ byte, v int) bool { return v >= 10 })
+	if !bf.Equals(new(bytemap.BitField)) {
+		t.Error("WhereBitField should be empty")
+	}
+	bf = m.WhereBitField(func(_ byte, v int) bool { return v > 2 })
+	if !bf.Equals(m.Above(2).ToBitField()) {
+		t.Error("WhereBitField differs from Above")
+	}
+	for _, tc := range []struct {
+		name      string
+		got, want *bytemap.BitField
+	}{
+		{"AboveBitField(2)", m.AboveBitField(2), m.Above(2).ToBitField()},
+		{"BelowBitField(0)", m.BelowBitField(0), m.Below(0).ToBitField()},
+		{"BetweenBitField(2, 3)", m.BetweenBitField(2, 3), m.Between(2, 3).ToBitField()},
+		{"TopKBitField(2)", m.TopKBitField(2), m.TopK(2).ToBitField()},
+	} {
+		if !tc.got.Equals(tc.want) {
+			t.Errorf("%s = %v; want %v", tc.name, tc.got.ToMap(), tc.want.ToMap())
+		}
+	}
+}
+
+func TestFloatSelect(t *testing.T) {
+	var m bytemap.Float
+	m.WriteString("aaaabbbccd")
+	m.SetFrequencies()
+	for _, tc := range []struct {
+		name string
+		got  *bytemap.Bool
+		want string
+	}{
+		{"Above(0.2)", m.Above(0.2), "[ab]"},
+		{"Below(0.25)", m.Below(0.25), "[^ab]"},
+		{"Between(0.2, 0.3)", m.Between(0.2, 0.3), "[bc]"},
+		{"TopK(3)", m.TopK(3), "[abc]"},
+		{"Where", m.Where(func(c byte, v float64) bool { return c == 'd' }), "[d]"},
+	} {
+		want := bytemap.MustParseClass(tc.want)
+		if !tc.got.Equals(want) {
+			t.Errorf("%s = %q; want %q", tc.name, tc.got.String(), want.String())
+		}
+	}
+	if got := m.WhereBitField(func(_ byte, v float64) bool { return v > 0.2 }); !got.Equals(m.Above(0.2).ToBitField()) {
+		t.Error("WhereBitField differs from Above")
+	}
+	for _, tc := range []struct {
+		name      string
+		got, want *bytemap.BitField
+	}{
+		{"AboveBitField(0.2)", m.AboveBitField(0.2), m.Above(0.2).ToBitField()},
+		{"BelowBitField(0.25)", m.BelowBitField(0.25), m.Below(0.25).ToBitField()},
+		{"BetweenBitField(0.2, 0.3)", m.BetweenBitField(0.2, 0.3), m.Between(0.2, 0.3).ToBitField()},
+		{"TopKBitField(3)", m.TopKBitField(3), m.TopK(3).ToBitField()},
+	} {
+		if !tc.got.Equals(tc.want) {
+			t.Errorf("%s = %v; want %v", tc.name, tc.got.ToMap(), tc.want.ToMap())
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Package sniff guesses the character encoding of text
+// from its byte counts and, optionally, its byte pair counts.
+package sniff
+
+import (
+	"cmp"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/earthboundkid/bytemap/v2"
+	"github.com/earthboundkid/bytemap/v2/internal/textclass"
+)
+
+// Encoding is a character encoding which Sniff can recognize.
+type Encoding int
+
+// Encodings which Sniff can recognize.
+const (
+	ASCII Encoding = iota
+	UTF8
+	Latin1
+	Windows1252
+	UTF16LE
+	UTF16BE
+	// Binary means the bytes are probably not text.
+	Binary
+)
+
+var encodingNames = [...]string{
+	ASCII:       "US-ASCII",
+	UTF8:        "UTF-8",
+	Latin1:      "ISO-8859-1",
+	Windows1252: "windows-1252",
+	UTF16LE:     "UTF-16LE",
+	UTF16BE:     "UTF-16BE",
+	Binary:      "binary",
+}
+
+// String returns the IANA name of the encoding, or "binary".
+func (e Encoding) String() string {
+	if e < 0 || int(e) >= len(encodingNames) {
+		return "Encoding(?)"
+	}
+	return encodingNames[e]
+}
+
+// Guess is a possible encoding with a confidence score from 0 to 1.
+// Scores are heuristic and are only meaningful relative to one another.
+type Guess struct {
+	Encoding   Encoding
+	Confidence float64
+}
+
+var (
+	all       = bytemap.Range(0x00, 0xff)
+	highBytes = bytemap.Range(0x80, 0xff)
+	// controls are the control characters which bytemap.Classify counts,
+	// so that the two agree on backspace and vertical tab
+	controls  = (*bytemap.Bool)(textclass.Control)
+	c1        = bytemap.Range(0x80, 0x9f)
+	undefined = bytemap.Make("\x81\x8d\x8f\x90\x9d")
+)
+
+func sum(counts *bytemap.Int, m *bytemap.Bool) int {
+	return textclass.Sum((*[bytemap.Len]int)(counts), (*[bytemap.Len]bool)(m))
+}
+
+// textness scores how free of control characters
+// n bytes with ctrl control characters are.
+func textness(ctrl, n int) float64 {
+	if n == 0 {
+		return 1
+	}
+	return max(1-10*float64(ctrl)/float64(n), 0)
+}
+
+// Parity counts the NULs at even and odd offsets of the bytes written to it.
+// The NULs of Latin text in UTF-16LE fall at odd offsets
+// and those in UTF-16BE at even offsets,
+// so Sniff uses Parity to tell the byte orders apart.
+// The zero value is ready to use.
+type Parity struct {
+	// Even and Odd are the numbers of NULs at even and odd offsets.
+	Even, Odd int
+	offset    int
+}
+
+var _ io.Writer = (*Parity)(nil)
+
+// Write satisfies io.Writer.
+func (p *Parity) Write(b []byte) (int, error) {
+	for i, c := range b {
+		if c == 0 {
+			if (p.offset+i)%2 == 0 {
+				p.Even++
+			} else {
+				p.Odd++
+			}
+		}
+	}
+	p.offset += len(b)
+	return len(b), nil
+}
+
+var _ io.StringWriter = (*Parity)(nil)
+
+// WriteString satisfies io.StringWriter.
+func (p *Parity) WriteString(s string) (n int, err error) {
+	return p.Write([]byte(s))
+}
+
+// Sniff ranks the possible encodings of text with the given byte counts
+// from most to least likely, omitting any with zero confidence.
+// If pairs is not nil, it is used to check UTF-8 sequences
+// and the alternating NULs of UTF-16.
+// Counts should cover whole characters,
+// since a multibyte character cut in half looks like invalid UTF-8.
+//
+// UTF-16 is only recognized by its NULs,
+// so it is only detected for mostly Latin text.
+// Its byte order is decided by parity if it is not nil and has NULs,
+// or else by a byte order mark among pairs.
+// Without either, UTF-16LE is ranked just above UTF-16BE.
+func Sniff(counts *bytemap.Int, pairs *bytemap.Pair, parity *Parity) []Guess {
+	var scores [len(encodingNames)]float64
+	n := sum(counts, all)
+	nul := max(counts[0], 0)
+	ctrl := sum(counts, controls)
+	high := sum(counts, highBytes)
+	text := textness(ctrl+nul, n)
+
+	valid := utf8Validity(counts, pairs, high)
+	if high == 0 {
+		scores[ASCII] = text
+		scores[UTF8] = 0.9 * text
+		scores[Latin1] = 0.5 * text
+		scores[Windows1252] = 0.5 * text
+	} else {
+		h := float64(high)
+		scores[UTF8] = valid * text
+		notUTF8 := 1 - 0.9*valid
+		scores[Latin1] = (1 - float64(sum(counts, c1))/h) * notUTF8 * text
+		scores[Windows1252] = (1 - float64(sum(counts, undefined))/h) * notUTF8 * text
+		if sum(counts, c1) == 0 {
+			// Latin-1 suffices, but Windows-1252 is a superset of it
+			scores[Windows1252] *= 0.95
+		}
+	}
+
+	le, be := utf16Score(pairs, parity, n, nul, ctrl)
+	scores[UTF16LE], scores[UTF16BE] = le, be
+	scores[Binary] = (1 - text) * (1 - max(le, be))
+
+	guesses := make([]Guess, 0, len(scores))
+	for e, score := range scores {
+		if score > 0 {
+			guesses = append(guesses, Guess{Encoding(e), score})
+		}
+	}
+	slices.SortStableFunc(guesses, func(a, b Guess) int {
+		return cmp.Compare(b.Confidence, a.Confidence)
+	})
+	return guesses
+}
+
+// utf8Validity scores how consistent the high bytes are with UTF-8,
+// from 0 for not at all to 1 for fully consistent.
+func utf8Validity(counts *bytemap.Int, pairs *bytemap.Pair, high int) float64 {
+	if high == 0 {
+		return 1
+	}
+	bad := textclass.UTF8Mismatches((*[bytemap.Len]int)(counts))
+	if pairs != nil {
+		for first := range bytemap.Len {
+			for second := range bytemap.Len {
+				v := pairs.Get(byte(first), byte(second))
+				if v <= 0 {
+					continue
+				}
+				isLead := textclass.Lead2[first] || textclass.Lead3[first] || textclass.Lead4[first]
+				switch {
+				case isLead && !textclass.Continuation[second]:
+					bad += v
+				case first < 0x80 && textclass.Continuation[second]:
+					bad += v
+				}
+			}
+		}
+	}
+	return max(1-4*float64(bad)/float64(high), 0)
+}
+
+// utf16Score scores how much the bytes look like UTF-16 text,
+// which has a NUL in every other byte for Latin characters.
+func utf16Score(pairs *bytemap.Pair, parity *Parity, n, nul, ctrl int) (le, be float64) {
+	if nul == 0 {
+		return 0, 0
+	}
+	text := textness(ctrl, n-nul)
+	score := max(1-4*math.Abs(float64(nul)/float64(n)-0.5), 0)
+	var bomLE, bomBE bool
+	if pairs != nil {
+		// NULs should alternate with other bytes
+		alternating, total := 0, 0
+		for first := range bytemap.Len {
+			for second := range bytemap.Len {
+				v := max(pairs.Get(byte(first), byte(second)), 0)
+				total += v
+				if (first == 0) != (second == 0) {
+					alternating += v
+				}
+			}
+		}
+		if total > 0 {
+			score = float64(alternating) / float64(total)
+		}
+		bomLE = pairs.Get(0xff, 0xfe) > 0
+		bomBE = pairs.Get(0xfe, 0xff) > 0
+	}
+	score *= text
+	// leShare is the fraction of the evidence for little endian
+	leShare := 0.5
+	switch {
+	case parity != nil && parity.Even+parity.Odd > 0:
+		leShare = float64(parity.Odd) / float64(parity.Even+parity.Odd)
+	case bomLE && !bomBE:
+		leShare = 1
+	case bomBE && !bomLE:
+		leShare = 0
+	}
+	le = score * min(2*leShare, 1)
+	be = score * min(2*(1-leShare), 1)
+	if le == be {
+		// Without evidence either way, prefer the more common little endian
+		be *= 0.95
+	}
+	return le, be
+}
+
+// SniffBytes ranks the possible encodings of b like Sniff.
+// If b begins with a byte order mark,
+// the encoding it marks is ranked first with a confidence of 1.
+func SniffBytes(b []byte) []Guess {
+	var counts bytemap.Int
+	counts.Write(b)
+	pairs := new(bytemap.Pair)
+	pairs.Write(b)
+	var parity Parity
+	parity.Write(b)
+	guesses := Sniff(&counts, pairs, &parity)
+	var bom Encoding = -1
+	switch {
+	case len(b) >= 3 && b[0] == 0xef && b[1] == 0xbb && b[2] == 0xbf:
+		bom = UTF8
+	case len(b) >= 2 && b[0] == 0xff && b[1] == 0xfe:
+		bom = UTF16LE
+	case len(b) >= 2 && b[0] == 0xfe && b[1] == 0xff:
+		bom = UTF16BE
+	}
+	if bom < 0 {
+		return guesses
+	}
+	guesses = slices.DeleteFunc(guesses, func(g Guess) bool {
+		return g.Encoding == bom
+	})
+	return slices.Insert(guesses, 0, Guess{bom, 1})
+}
@@ -0,0 +1,26 @@
+package sniff_test
+
+import (
+	"fmt"
+
+	"github.com/earthboundkid/bytemap/v2/sniff"
+)
+
+func ExampleSniffBytes() {
+	for _, text := range []string{
+		"plain old text",
+		"caf\xc3\xa9 cr\xc3\xa8me",
+		"caf\xe9 cr\xe8me",
+		"\x93smart quotes\x94",
+		"t\x00e\x00x\x00t\x00",
+	} {
+		guesses := sniff.SniffBytes([]byte(text))
+		fmt.Printf("%q: %v (%.2f)\n", text, guesses[0].Encoding, guesses[0].Confidence)
+	}
+	// Output:
+	// "plain old text": US-ASCII (1.00)
+	// "café crème": UTF-8 (1.00)
+	// "caf\xe9 cr\xe8me": ISO-8859-1 (1.00)
+	// "\x93smart quotes\x94": windows-1252 (1.00)
+	// "t\x00e\x00x\x00t\x00": UTF-16LE (1.00)
+}
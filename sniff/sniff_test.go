@@ -0,0 +1,144 @@
+package sniff_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/earthboundkid/bytemap/v2"
+	"github.com/earthboundkid/bytemap/v2/sniff"
+)
+
+const sample = "Zoë went to the café, naïvely ordering a crème brûlée. "
+
+func latin1(s string) []byte {
+	var b []byte
+	for _, r := range s {
+		b = append(b, byte(r))
+	}
+	return b
+}
+
+func utf16Bytes(s string, order binary.AppendByteOrder) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = order.AppendUint16(b, u)
+	}
+	return b
+}
+
+func TestSniff(t *testing.T) {
+	mobyDick, err := os.ReadFile("../testdata/moby-dick.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	random := make([]byte, 10_000)
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range random {
+		random[i] = byte(r.Uint32())
+	}
+	// Smart quotes and an em dash from Windows-1252
+	windows := bytes.Repeat([]byte("\x93Quoted\x94 text \x96 with \x92smart\x92 punctuation. "), 10)
+	for _, tc := range []struct {
+		name string
+		data []byte
+		want sniff.Encoding
+	}{
+		{"empty", nil, sniff.ASCII},
+		{"ascii", []byte(strings.Repeat("Hello, world!\r\n", 10)), sniff.ASCII},
+		{"moby-dick", mobyDick, sniff.UTF8},
+		{"utf-8", []byte(strings.Repeat(sample, 10)), sniff.UTF8},
+		{"latin-1", latin1(strings.Repeat(sample, 10)), sniff.Latin1},
+		{"windows-1252", windows, sniff.Windows1252},
+		{"utf-16le", utf16Bytes(strings.Repeat(sample, 10), binary.LittleEndian), sniff.UTF16LE},
+		{"utf-16be", utf16Bytes(strings.Repeat(sample, 10), binary.BigEndian), sniff.UTF16BE},
+		{"utf-16be bom", utf16Bytes("\ufeff"+strings.Repeat(sample, 10), binary.BigEndian), sniff.UTF16BE},
+		{"random", random, sniff.Binary},
+		{"nul padded", append(bytes.Repeat([]byte{0}, 1000), "\x01\x02\x03header"...), sniff.Binary},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			guesses := sniff.SniffBytes(tc.data)
+			if len(guesses) == 0 || guesses[0].Encoding != tc.want {
+				t.Fatalf("got %v; want %v first", guesses, tc.want)
+			}
+			for i, g := range guesses {
+				if g.Confidence <= 0 || g.Confidence > 1 ||
+					i > 0 && g.Confidence > guesses[i-1].Confidence {
+					t.Fatalf("bad ranking %v", guesses)
+				}
+			}
+		})
+	}
+}
+
+func TestSniffWithoutPairs(t *testing.T) {
+	var counts bytemap.Int
+	counts.Write(latin1(strings.Repeat(sample, 10)))
+	guesses := sniff.Sniff(&counts, nil, nil)
+	if guesses[0].Encoding != sniff.Latin1 {
+		t.Fatal(guesses)
+	}
+	counts = bytemap.Int{}
+	counts.WriteString(strings.Repeat(sample, 10))
+	guesses = sniff.Sniff(&counts, nil, nil)
+	if guesses[0].Encoding != sniff.UTF8 {
+		t.Fatal(guesses)
+	}
+}
+
+func TestSniffParity(t *testing.T) {
+	for _, order := range []struct {
+		order binary.AppendByteOrder
+		want  sniff.Encoding
+	}{
+		{binary.LittleEndian, sniff.UTF16LE},
+		{binary.BigEndian, sniff.UTF16BE},
+	} {
+		text := utf16Bytes(strings.Repeat(sample, 10), order.order)
+		var counts bytemap.Int
+		counts.Write(text)
+		var parity sniff.Parity
+		// Split the writes at an odd offset
+		parity.Write(text[:5])
+		parity.Write(text[5:])
+		guesses := sniff.Sniff(&counts, nil, &parity)
+		if guesses[0].Encoding != order.want {
+			t.Fatalf("got %v; want %v first", guesses, order.want)
+		}
+		// A byte order mark among the pairs decides without parity
+		bom := utf16Bytes("\ufeff", order.order)
+		pairs := new(bytemap.Pair)
+		pairs.Write(bom)
+		pairs.Write(text)
+		counts.Write(bom)
+		guesses = sniff.Sniff(&counts, pairs, nil)
+		if guesses[0].Encoding != order.want {
+			t.Fatalf("BOM: got %v; want %v first", guesses, order.want)
+		}
+	}
+}
+
+func TestSniffBytesBOM(t *testing.T) {
+	guesses := sniff.SniffBytes([]byte("\xef\xbb\xbfplain"))
+	if guesses[0] != (sniff.Guess{Encoding: sniff.UTF8, Confidence: 1}) {
+		t.Fatal(guesses)
+	}
+	for _, g := range guesses[1:] {
+		if g.Encoding == sniff.UTF8 {
+			t.Fatal("duplicate guess", guesses)
+		}
+	}
+}
+
+func TestEncodingString(t *testing.T) {
+	if got := sniff.UTF16BE.String(); got != "UTF-16BE" {
+		t.Fatal(got)
+	}
+	if got := sniff.Encoding(100).String(); got != "Encoding(?)" {
+		t.Fatal(got)
+	}
+}
@@ -0,0 +1,207 @@
+package bytemap
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrOverflow is returned when a sum of Int values overflows an int.
+var ErrOverflow = errors.New("integer overflow")
+
+// Stats summarizes the values of an Int or Float.
+//
+// An Int or Float is treated as a histogram of byte values,
+// so Mean and Variance describe the byte values weighted by their counts.
+// Bytes with negative counts are given no weight.
+type Stats[V int | float64] struct {
+	// Sum is the sum of all values.
+	Sum V
+	// Overflow reports whether Sum overflowed.
+	Overflow bool
+	// Nonzero is the number of bytes with a non-zero value.
+	Nonzero int
+	// Min and Max are the smallest and largest values.
+	Min, Max V
+	// ArgMin and ArgMax are the first bytes with the values Min and Max.
+	ArgMin, ArgMax byte
+	// Mean is the mean byte value, or NaN if there are no positive counts.
+	Mean float64
+	// Variance is the population variance of the byte values,
+	// or NaN if there are no positive counts.
+	Variance float64
+}
+
+func stats[V int | float64](m *[Len]V) Stats[V] {
+	s := Stats[V]{Min: m[0], Max: m[0]}
+	var weight, sum1, sum2 float64
+	for i, v := range m {
+		next := s.Sum + v
+		if v > 0 && next < s.Sum || v < 0 && next > s.Sum {
+			s.Overflow = true
+		}
+		s.Sum = next
+		if v != 0 {
+			s.Nonzero++
+		}
+		if v < s.Min {
+			s.Min, s.ArgMin = v, byte(i)
+		}
+		if v > s.Max {
+			s.Max, s.ArgMax = v, byte(i)
+		}
+		if v > 0 {
+			w := float64(v)
+			weight += w
+			sum1 += w * float64(i)
+			sum2 += w * float64(i) * float64(i)
+		}
+	}
+	s.Mean = sum1 / weight
+	s.Variance = max(sum2/weight-s.Mean*s.Mean, 0)
+	if weight == 0 {
+		s.Mean, s.Variance = math.NaN(), math.NaN()
+	}
+	return s
+}
+
+// percentile returns the smallest byte
+// whose cumulative weight is at least p percent of the total.
+func percentile[V int | float64](m *[Len]V, p float64) (byte, bool) {
+	var total float64
+	for _, v := range m {
+		total += float64(max(v, 0))
+	}
+	if total == 0 || math.IsNaN(p) {
+		return 0, false
+	}
+	target := min(max(p, 0), 100) / 100 * total
+	var cum float64
+	last := 0
+	for i, v := range m {
+		if v <= 0 {
+			continue
+		}
+		cum += float64(v)
+		last = i
+		if cum >= target {
+			return byte(i), true
+		}
+	}
+	// Rounding error can leave cum just below total
+	return byte(last), true
+}
+
+// Stats summarizes m in one pass.
+func (m *Int) Stats() Stats[int] {
+	return stats((*[Len]int)(m))
+}
+
+// Sum returns the sum of the values in m.
+// If the sum overflows an int, it returns ErrOverflow.
+func (m *Int) Sum() (int, error) {
+	s := m.Stats()
+	if s.Overflow {
+		return 0, ErrOverflow
+	}
+	return s.Sum, nil
+}
+
+// Nonzero returns the number of bytes in m with a non-zero count.
+func (m *Int) Nonzero() int {
+	return m.Stats().Nonzero
+}
+
+// Min returns the first byte with the smallest count in m and its count.
+func (m *Int) Min() (byte, int) {
+	s := m.Stats()
+	return s.ArgMin, s.Min
+}
+
+// Max returns the first byte with the largest count in m and its count.
+func (m *Int) Max() (byte, int) {
+	s := m.Stats()
+	return s.ArgMax, s.Max
+}
+
+// Mode returns the most common byte in m.
+// If no byte has a positive count, it returns 0, false.
+func (m *Int) Mode() (byte, bool) {
+	c, n := m.Max()
+	return c, n > 0
+}
+
+// Mean returns the mean byte value in m, weighted by count.
+// If no byte has a positive count, it returns NaN.
+func (m *Int) Mean() float64 {
+	return m.Stats().Mean
+}
+
+// Variance returns the population variance of the byte values in m,
+// weighted by count.
+// If no byte has a positive count, it returns NaN.
+func (m *Int) Variance() float64 {
+	return m.Stats().Variance
+}
+
+// Percentile returns the smallest byte
+// such that at least p percent of the counts in m are for it or smaller bytes.
+// The percentile p is clamped to the range 0 to 100.
+// If no byte has a positive count, it returns 0, false.
+func (m *Int) Percentile(p float64) (byte, bool) {
+	return percentile((*[Len]int)(m), p)
+}
+
+// Stats summarizes m in one pass.
+func (m *Float) Stats() Stats[float64] {
+	return stats((*[Len]float64)(m))
+}
+
+// Sum returns the sum of the values in m.
+func (m *Float) Sum() float64 {
+	return m.Stats().Sum
+}
+
+// Nonzero returns the number of bytes in m with a non-zero value.
+func (m *Float) Nonzero() int {
+	return m.Stats().Nonzero
+}
+
+// Min returns the first byte with the smallest value in m and its value.
+func (m *Float) Min() (byte, float64) {
+	s := m.Stats()
+	return s.ArgMin, s.Min
+}
+
+// Max returns the first byte with the largest value in m and its value.
+func (m *Float) Max() (byte, float64) {
+	s := m.Stats()
+	return s.ArgMax, s.Max
+}
+
+// Mode returns the byte with the largest value in m.
+// If no byte has a positive value, it returns 0, false.
+func (m *Float) Mode() (byte, bool) {
+	c, n := m.Max()
+	return c, n > 0
+}
+
+// Mean returns the mean byte value in m, weighted by value.
+// If no byte has a positive value, it returns NaN.
+func (m *Float) Mean() float64 {
+	return m.Stats().Mean
+}
+
+// Variance returns the population variance of the byte values in m,
+// weighted by value.
+// If no byte has a positive value, it returns NaN.
+func (m *Float) Variance() float64 {
+	return m.Stats().Variance
+}
+
+// Percentile returns the smallest byte
+// such that at least p percent of the values in m are for it or smaller bytes.
+// The percentile p is clamped to the range 0 to 100.
+// If no byte has a positive value, it returns 0, false.
+func (m *Float) Percentile(p float64) (byte, bool) {
+	return percentile((*[Len]float64)(m), p)
+}
@@ -0,0 +1,178 @@
+package bytemap_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func TestIntStats(t *testing.T) {
+	var m bytemap.Int
+	m.WriteString("aabbbc")
+	m['z'] = -1
+	s := m.Stats()
+	mean := (2*97.0 + 3*98 + 99) / 6
+	variance := (2*(97-mean)*(97-mean) + 3*(98-mean)*(98-mean) + (99-mean)*(99-mean)) / 6
+	want := bytemap.Stats[int]{
+		Sum:      5,
+		Nonzero:  4,
+		Min:      -1,
+		ArgMin:   'z',
+		Max:      3,
+		ArgMax:   'b',
+		Mean:     mean,
+		Variance: variance,
+	}
+	if math.Abs(s.Mean-want.Mean) > 1e-9 || math.Abs(s.Variance-want.Variance) > 1e-9 {
+		t.Fatalf("got %+v; want %+v", s, want)
+	}
+	s.Mean, s.Variance = want.Mean, want.Variance
+	if s != want {
+		t.Fatalf("got %+v; want %+v", s, want)
+	}
+	if sum, err := m.Sum(); sum != 5 || err != nil {
+		t.Fatal(sum, err)
+	}
+	if m.Nonzero() != 4 {
+		t.Fatal(m.Nonzero())
+	}
+	if c, n := m.Min(); c != 'z' || n != -1 {
+		t.Fatal(c, n)
+	}
+	if c, n := m.Max(); c != 'b' || n != 3 {
+		t.Fatal(c, n)
+	}
+	if c, ok := m.Mode(); c != 'b' || !ok {
+		t.Fatal(c, ok)
+	}
+	for _, tc := range []struct {
+		p    float64
+		want byte
+	}{
+		{-10, 'a'},
+		{0, 'a'},
+		{33, 'a'},
+		{34, 'b'},
+		{50, 'b'},
+		{83, 'b'},
+		{84, 'c'},
+		{100, 'c'},
+		{200, 'c'},
+	} {
+		if got, ok := m.Percentile(tc.p); got != tc.want || !ok {
+			t.Errorf("Percentile(%v) = %q, %v; want %q", tc.p, got, ok, tc.want)
+		}
+	}
+}
+
+func TestIntStatsOverflow(t *testing.T) {
+	var m bytemap.Int
+	m['a'] = math.MaxInt
+	m['b'] = 1
+	if _, err := m.Sum(); err != bytemap.ErrOverflow {
+		t.Fatal(err)
+	}
+	if s := m.Stats(); !s.Overflow {
+		t.Fatal(s)
+	}
+	if mean := m.Mean(); math.Abs(mean-'a') > 1e-9 {
+		t.Fatal(mean)
+	}
+	m['a'] = math.MinInt
+	m['b'] = -1
+	if _, err := m.Sum(); err != bytemap.ErrOverflow {
+		t.Fatal(err)
+	}
+}
+
+func TestStatsEmpty(t *testing.T) {
+	var m bytemap.Int
+	if sum, err := m.Sum(); sum != 0 || err != nil {
+		t.Fatal(sum, err)
+	}
+	if !math.IsNaN(m.Mean()) || !math.IsNaN(m.Variance()) {
+		t.Fatal(m.Mean(), m.Variance())
+	}
+	if _, ok := m.Mode(); ok {
+		t.Fatal("empty Int has no mode")
+	}
+	if _, ok := m.Percentile(50); ok {
+		t.Fatal("empty Int has no percentile")
+	}
+	var f bytemap.Float
+	if !math.IsNaN(f.Mean()) || !math.IsNaN(f.Variance()) {
+		t.Fatal(f.Mean(), f.Variance())
+	}
+	if _, ok := f.Mode(); ok {
+		t.Fatal("empty Float has no mode")
+	}
+	if _, ok := f.Percentile(50); ok {
+		t.Fatal("empty Float has no percentile")
+	}
+}
+
+func FuzzStats(f *testing.F) {
+	f.Add("")
+	f.Add("a")
+	f.Add("hello, world")
+	f.Add("\x00\xff")
+	f.Fuzz(func(t *testing.T, s string) {
+		var mInt bytemap.Int
+		mInt.WriteString(s)
+		mFloat := mInt.ToFloat()
+		si, sf := mInt.Stats(), mFloat.Stats()
+		if float64(si.Sum) != sf.Sum || si.Sum != len(s) ||
+			si.Nonzero != sf.Nonzero || si.Overflow || sf.Overflow ||
+			si.ArgMin != sf.ArgMin || si.ArgMax != sf.ArgMax ||
+			float64(si.Min) != sf.Min || float64(si.Max) != sf.Max {
+			t.Fatalf("%+v != %+v", si, sf)
+		}
+		if s == "" {
+			return
+		}
+		var mean, variance float64
+		for _, c := range []byte(s) {
+			mean += float64(c)
+		}
+		mean /= float64(len(s))
+		for _, c := range []byte(s) {
+			variance += (float64(c) - mean) * (float64(c) - mean)
+		}
+		variance /= float64(len(s))
+		for _, got := range []float64{si.Mean, sf.Mean, mFloat.Mean()} {
+			if math.Abs(got-mean) > 1e-6 {
+				t.Fatal(got, mean)
+			}
+		}
+		for _, got := range []float64{si.Variance, sf.Variance, mInt.Variance()} {
+			if math.Abs(got-variance) > 1e-6 {
+				t.Fatal(got, variance)
+			}
+		}
+		mFloat.SetFrequencies()
+		for _, p := range []float64{0, 1, 25, 50, 75, 99, 100} {
+			ci, _ := mInt.Percentile(p)
+			cf, _ := mFloat.Percentile(p)
+			// The proportion of s at or below the percentile
+			// must be at least p, and strictly less without it.
+			var atOrBelow, below int
+			for _, c := range []byte(s) {
+				if c <= ci {
+					atOrBelow++
+				}
+				if c < ci {
+					below++
+				}
+			}
+			if float64(atOrBelow) < p/100*float64(len(s)) ||
+				float64(below) >= p/100*float64(len(s)) && p > 0 {
+				t.Fatal(p, ci)
+			}
+			// Frequencies can round differently at exact boundaries
+			if cf != ci && math.Abs(float64(atOrBelow)-p/100*float64(len(s))) > 1e-6 {
+				t.Fatal(p, ci, cf)
+			}
+		}
+	})
+}
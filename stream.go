@@ -0,0 +1,137 @@
+package bytemap
+
+import (
+	"fmt"
+	"io"
+)
+
+// FilterReader returns a reader which reads from r
+// and keeps only the bytes which are in m.
+// To drop the bytes in m instead, filter with m.Invert().
+func FilterReader(r io.Reader, m *Bool) io.Reader {
+	return &filterReader{r, m}
+}
+
+type filterReader struct {
+	r io.Reader
+	m *Bool
+}
+
+func (fr *filterReader) Read(p []byte) (int, error) {
+	for {
+		n, err := fr.r.Read(p)
+		j := 0
+		for _, c := range p[:n] {
+			if fr.m[c] {
+				p[j] = c
+				j++
+			}
+		}
+		// Don't report 0, nil just because everything was filtered out
+		if j > 0 || err != nil || len(p) == 0 {
+			return j, err
+		}
+	}
+}
+
+// ReplaceWriter returns a writer which translates bytes with m
+// before writing them to w.
+// The slices passed to Write are not modified.
+func ReplaceWriter(w io.Writer, m *Byte) io.Writer {
+	return &replaceWriter{w, m}
+}
+
+type replaceWriter struct {
+	w io.Writer
+	m *Byte
+}
+
+func (rw *replaceWriter) Write(p []byte) (int, error) {
+	n := 0
+	var buf [4096]byte
+	for len(p) > 0 {
+		chunk := buf[:min(len(p), len(buf))]
+		for i := range chunk {
+			chunk[i] = rw.m[p[i]]
+		}
+		nw, err := rw.w.Write(chunk)
+		n += nw
+		if err != nil {
+			return n, err
+		}
+		if nw != len(chunk) {
+			return n, io.ErrShortWrite
+		}
+		p = p[nw:]
+	}
+	return n, nil
+}
+
+var _ io.StringWriter = (*replaceWriter)(nil)
+
+func (rw *replaceWriter) WriteString(s string) (int, error) {
+	n := 0
+	var buf [4096]byte
+	for len(s) > 0 {
+		chunk := buf[:min(len(s), len(buf))]
+		for i := range chunk {
+			chunk[i] = rw.m[s[i]]
+		}
+		nw, err := rw.w.Write(chunk)
+		n += nw
+		if err != nil {
+			return n, err
+		}
+		if nw != len(chunk) {
+			return n, io.ErrShortWrite
+		}
+		s = s[nw:]
+	}
+	return n, nil
+}
+
+// InvalidByteError is returned by a ValidatingReader
+// when it reads a byte which is not in its Bool.
+type InvalidByteError struct {
+	Byte   byte
+	Offset int64
+}
+
+func (e *InvalidByteError) Error() string {
+	return fmt.Sprintf("invalid byte %q at offset %d", e.Byte, e.Offset)
+}
+
+// ValidatingReader returns a reader which reads from r
+// as long as every byte read is in m.
+// When it reads a byte which is not in m,
+// it returns the bytes preceding it along with an *InvalidByteError,
+// and it returns the same error on all subsequent reads.
+func ValidatingReader(r io.Reader, m *Bool) io.Reader {
+	return &validatingReader{r: r, m: m}
+}
+
+type validatingReader struct {
+	r   io.Reader
+	m   *Bool
+	off int64
+	err error
+}
+
+func (vr *validatingReader) Read(p []byte) (int, error) {
+	if vr.err != nil {
+		return 0, vr.err
+	}
+	n, err := vr.r.Read(p)
+	for i, c := range p[:n] {
+		if !vr.m[c] {
+			vr.err = &InvalidByteError{c, vr.off + int64(i)}
+			vr.off += int64(i)
+			return i, vr.err
+		}
+	}
+	vr.off += int64(n)
+	if err != nil && err != io.EOF {
+		vr.err = err
+	}
+	return n, err
+}
@@ -0,0 +1,56 @@
+package bytemap_test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func ExampleFilterReader() {
+	vowels := bytemap.Make("aeiouAEIOU")
+	r := bytemap.FilterReader(strings.NewReader("Hello, world!"), vowels.Invert())
+	_, _ = io.Copy(os.Stdout, r)
+	fmt.Println()
+	// Output:
+	// Hll, wrld!
+}
+
+func ExampleReplaceWriter() {
+	upper := bytemap.Translate("a-z", "A-Z")
+	w := bytemap.ReplaceWriter(os.Stdout, upper)
+	_, _ = io.Copy(w, strings.NewReader("Hello, world!\n"))
+	// Output:
+	// HELLO, WORLD!
+}
+
+func ExampleValidatingReader() {
+	digits := bytemap.Range('0', '9')
+	r := bytemap.ValidatingReader(strings.NewReader("8675309x"), digits)
+	b, err := io.ReadAll(r)
+	fmt.Printf("%q\n", b)
+	fmt.Println(err)
+	// Output:
+	// "8675309"
+	// invalid byte 'x' at offset 7
+}
+
+func ExampleIsText() {
+	for _, s := range []string{
+		"Hello, world!\n",
+		"Hello, 世界!\n",
+		"\x7fELF\x02\x01\x01\x00",
+	} {
+		ok, err := bytemap.IsText(strings.NewReader(s), 0)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("%q: %v\n", s, ok)
+	}
+	// Output:
+	// "Hello, world!\n": true
+	// "Hello, 世界!\n": true
+	// "\x7fELF\x02\x01\x01\x00": false
+}
@@ -0,0 +1,161 @@
+package bytemap_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func FuzzFilterReader(f *testing.F) {
+	f.Add("", "")
+	f.Add("hello, world", "lo")
+	f.Add("hello, world", "xyz")
+	f.Fuzz(func(t *testing.T, s, charset string) {
+		m := bytemap.Make(charset)
+		var want []byte
+		for _, c := range []byte(s) {
+			if m[c] {
+				want = append(want, c)
+			}
+		}
+		for _, r := range []io.Reader{
+			strings.NewReader(s),
+			iotest.OneByteReader(strings.NewReader(s)),
+			iotest.HalfReader(strings.NewReader(s)),
+		} {
+			got, err := io.ReadAll(bytemap.FilterReader(r, m))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("got %q; want %q", got, want)
+			}
+		}
+		if err := iotest.TestReader(
+			bytemap.FilterReader(strings.NewReader(s), m), want,
+		); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzReplaceWriter(f *testing.F) {
+	f.Add("", "", "")
+	f.Add("hello, world", "a-z", "A-Z")
+	f.Add(strings.Repeat("abc", 5000), "abc", "xyz")
+	f.Fuzz(func(t *testing.T, s, from, to string) {
+		if to == "" || strings.Contains(from, "-") || strings.Contains(to, "-") {
+			t.Skip()
+		}
+		m := bytemap.Translate(from, to)
+		want := m.ApplyString(s)
+		orig := []byte(s)
+		var buf bytes.Buffer
+		w := bytemap.ReplaceWriter(&buf, m)
+		n, err := w.Write(orig)
+		if err != nil || n != len(s) {
+			t.Fatal(n, err)
+		}
+		if buf.String() != want {
+			t.Fatalf("got %q; want %q", &buf, want)
+		}
+		if string(orig) != s {
+			t.Fatal("input was modified")
+		}
+		buf.Reset()
+		n64, err := io.Copy(w, iotest.HalfReader(strings.NewReader(s)))
+		if err != nil || n64 != int64(len(s)) {
+			t.Fatal(n64, err)
+		}
+		if buf.String() != want {
+			t.Fatalf("got %q; want %q", &buf, want)
+		}
+		buf.Reset()
+		n, err = io.WriteString(w, s)
+		if err != nil || n != len(s) {
+			t.Fatal(n, err)
+		}
+		if buf.String() != want {
+			t.Fatalf("got %q; want %q", &buf, want)
+		}
+	})
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	return len(p) / 2, nil
+}
+
+func TestReplaceWriterError(t *testing.T) {
+	w := bytemap.ReplaceWriter(shortWriter{}, bytemap.Identity())
+	n, err := w.Write(make([]byte, 100))
+	if err != io.ErrShortWrite || n != 50 {
+		t.Fatal(n, err)
+	}
+	wantErr := errors.New("oops")
+	w = bytemap.ReplaceWriter(errWriter{wantErr}, bytemap.Identity())
+	if _, err = w.Write([]byte("x")); err != wantErr {
+		t.Fatal(err)
+	}
+}
+
+type errWriter struct{ err error }
+
+func (ew errWriter) Write(p []byte) (int, error) {
+	return 0, ew.err
+}
+
+func TestValidatingReader(t *testing.T) {
+	digits := bytemap.Range('0', '9')
+	for _, tc := range []struct {
+		in     string
+		want   string
+		offset int64
+		bad    byte
+	}{
+		{"", "", -1, 0},
+		{"0123456789", "0123456789", -1, 0},
+		{"0123x456789", "0123", 4, 'x'},
+		{"x", "", 0, 'x'},
+		{strings.Repeat("1", 10000) + "\n", strings.Repeat("1", 10000), 10000, '\n'},
+	} {
+		for _, r := range []io.Reader{
+			strings.NewReader(tc.in),
+			iotest.OneByteReader(strings.NewReader(tc.in)),
+			iotest.DataErrReader(strings.NewReader(tc.in)),
+		} {
+			vr := bytemap.ValidatingReader(r, digits)
+			got, err := io.ReadAll(vr)
+			if string(got) != tc.want {
+				t.Fatalf("got %q; want %q", got, tc.want)
+			}
+			if tc.offset == -1 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			var ibe *bytemap.InvalidByteError
+			if !errors.As(err, &ibe) {
+				t.Fatalf("got error %v", err)
+			}
+			if ibe.Offset != tc.offset || ibe.Byte != tc.bad {
+				t.Fatalf("got %v; want %q at %d", ibe, tc.bad, tc.offset)
+			}
+			if _, err2 := vr.Read(make([]byte, 1)); err2 != err {
+				t.Fatal("error should be sticky", err2)
+			}
+		}
+	}
+	wantErr := errors.New("oops")
+	vr := bytemap.ValidatingReader(iotest.ErrReader(wantErr), digits)
+	if _, err := io.ReadAll(vr); err != wantErr {
+		t.Fatal(err)
+	}
+}
@@ -70,3 +70,10 @@ func testGet[M ByteMap[T], T comparable](t *testing.T, m1 M, m2 map[byte]T) {
 		}
 	}
 }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
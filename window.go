@@ -0,0 +1,178 @@
+package bytemap
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// Crossing describes the entropy of a Window crossing its threshold.
+type Crossing struct {
+	// Offset is the total number of bytes written when the crossing occurred.
+	// The window covers the bytes just before Offset.
+	Offset int64
+	// Entropy is the entropy of the window after the crossing.
+	Entropy float64
+	// Rising is true if the entropy rose above the threshold
+	// and false if it fell back to or below it.
+	Rising bool
+}
+
+// Window is a histogram of the last bytes written to it.
+// Each byte written updates the histogram in constant time.
+// A Window must be created with NewWindow,
+// since the zero value has no size, and writing to it panics.
+type Window struct {
+	counts Int
+	buf    []byte
+	pos    int
+	full   bool
+	offset int64
+	// xlogx is the sum of c·log2(c) over the counts c,
+	// which lets Entropy run in constant time.
+	xlogx float64
+	// stale is the number of bytes written since xlogx was recomputed.
+	stale     int
+	threshold float64
+	onCross   func(Crossing)
+	above     bool
+}
+
+// NewWindow returns a Window over the last size bytes written.
+// It panics if size is less than 1.
+func NewWindow(size int) *Window {
+	if size < 1 {
+		panic(fmt.Errorf("invalid window size: %d", size))
+	}
+	return &Window{buf: make([]byte, size)}
+}
+
+// recomputeInterval is how many bytes a Window takes between recomputing
+// xlogx from scratch. Each recompute sums Len counts,
+// so an interval much larger than Len keeps the cost per byte low,
+// no matter how small the window is.
+const recomputeInterval = 16 * Len
+
+func xlog2x(c int) float64 {
+	if c <= 1 {
+		return 0
+	}
+	x := float64(c)
+	return x * math.Log2(x)
+}
+
+func (w *Window) writeByte(c byte) {
+	if w.full {
+		old := w.buf[w.pos]
+		n := w.counts[old]
+		w.xlogx += xlog2x(n-1) - xlog2x(n)
+		w.counts[old] = n - 1
+	}
+	n := w.counts[c]
+	w.xlogx += xlog2x(n+1) - xlog2x(n)
+	w.counts[c] = n + 1
+	w.buf[w.pos] = c
+	w.offset++
+	w.pos++
+	if w.pos == len(w.buf) {
+		w.pos = 0
+		w.full = true
+	}
+	w.stale++
+	if w.stale == recomputeInterval {
+		// Recompute to keep rounding errors from accumulating
+		w.stale = 0
+		w.xlogx = 0
+		for _, n := range w.counts {
+			w.xlogx += xlog2x(n)
+		}
+	}
+	if w.full && w.onCross != nil {
+		h := w.Entropy()
+		if above := h > w.threshold; above != w.above {
+			w.above = above
+			w.onCross(Crossing{w.offset, h, above})
+		}
+	}
+}
+
+func (w *Window) checkSize() {
+	if len(w.buf) == 0 {
+		panic("bytemap: write to Window with no size; use NewWindow")
+	}
+}
+
+var _ io.Writer = (*Window)(nil)
+
+// Write satisfies io.Writer.
+func (w *Window) Write(p []byte) (int, error) {
+	w.checkSize()
+	for _, c := range p {
+		w.writeByte(c)
+	}
+	return len(p), nil
+}
+
+var _ io.StringWriter = (*Window)(nil)
+
+// WriteString satisfies io.StringWriter.
+func (w *Window) WriteString(s string) (n int, err error) {
+	w.checkSize()
+	for _, c := range []byte(s) {
+		w.writeByte(c)
+	}
+	return len(s), nil
+}
+
+// Size returns the number of bytes w can hold.
+func (w *Window) Size() int {
+	return len(w.buf)
+}
+
+// Len returns the number of bytes currently in w,
+// which is less than Size until Size bytes have been written.
+func (w *Window) Len() int {
+	if w.full {
+		return len(w.buf)
+	}
+	return w.pos
+}
+
+// Offset returns the total number of bytes written to w.
+func (w *Window) Offset() int64 {
+	return w.offset
+}
+
+// Counts returns a copy of the counts of the bytes currently in w.
+func (w *Window) Counts() *Int {
+	return w.counts.Clone()
+}
+
+// Entropy returns the Shannon entropy of the bytes currently in w
+// in bits per byte.
+// If w is empty, it returns 0.
+func (w *Window) Entropy() float64 {
+	n := w.Len()
+	if n == 0 {
+		return 0
+	}
+	x := float64(n)
+	return max(math.Log2(x)-w.xlogx/x, 0)
+}
+
+// OnCross sets fn to be called whenever the entropy of w
+// rises above threshold or falls back to or below it.
+// Crossings are only reported once w is full,
+// and a full window starts out counted as below threshold.
+// Calling OnCross again replaces the threshold and callback.
+// A nil fn stops reporting crossings.
+func (w *Window) OnCross(threshold float64, fn func(Crossing)) {
+	w.threshold, w.onCross, w.above = threshold, fn, false
+}
+
+// Reset empties w, keeping its size and crossing callback.
+func (w *Window) Reset() {
+	clear(w.buf)
+	w.counts = Int{}
+	w.pos, w.full, w.offset, w.xlogx, w.stale, w.above = 0, false, 0, 0, 0, false
+}
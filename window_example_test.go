@@ -0,0 +1,21 @@
+package bytemap_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func ExampleWindow_OnCross() {
+	w := bytemap.NewWindow(16)
+	w.OnCross(3.5, func(c bytemap.Crossing) {
+		fmt.Printf("offset %d: rising %v\n", c.Offset, c.Rising)
+	})
+	w.WriteString(strings.Repeat("ab", 16))
+	w.WriteString("0123456789abcdef")
+	w.WriteString(strings.Repeat("ab", 16))
+	// Output:
+	// offset 45: rising true
+	// offset 52: rising false
+}
@@ -0,0 +1,98 @@
+package bytemap_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/earthboundkid/bytemap/v2"
+)
+
+func FuzzWindow(f *testing.F) {
+	f.Add("", 1)
+	f.Add("hello, world", 4)
+	f.Add("aaaaaaaabbbbbbbb", 3)
+	f.Fuzz(func(t *testing.T, s string, size int) {
+		size = 1 + abs(size)%32
+		w := bytemap.NewWindow(size)
+		for i := range len(s) {
+			w.WriteString(s[i : i+1])
+			start := max(i+1-size, 0)
+			var want bytemap.Int
+			want.WriteString(s[start : i+1])
+			if got := w.Counts(); !got.Equals(&want) {
+				t.Fatalf("%q[%d]: counts %v; want %v", s, i, got.ToMap(), want.ToMap())
+			}
+			if w.Len() != i+1-start || w.Offset() != int64(i+1) {
+				t.Fatalf("%q[%d]: Len %d Offset %d", s, i, w.Len(), w.Offset())
+			}
+			if got, want := w.Entropy(), want.Entropy(); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("%q[%d]: Entropy %v; want %v", s, i, got, want)
+			}
+		}
+	})
+}
+
+func TestWindowDrift(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	b := make([]byte, 1_000_003)
+	for i := range b {
+		b[i] = byte(r.IntN(1 + i/10_000))
+	}
+	for _, size := range []int{1, 3, 1000, 5000} {
+		w := bytemap.NewWindow(size)
+		w.Write(b)
+		if got, want := w.Entropy(), w.Counts().Entropy(); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("size %d: Entropy %v; want %v", size, got, want)
+		}
+	}
+}
+
+func TestWindowOnCross(t *testing.T) {
+	w := bytemap.NewWindow(4)
+	var crossings []bytemap.Crossing
+	w.OnCross(1.5, func(c bytemap.Crossing) {
+		crossings = append(crossings, c)
+	})
+	w.WriteString("abcd") // 2 bits when full
+	w.WriteString("aaaa") // 0 bits
+	w.WriteString("aaaa") // no change
+	w.WriteString("bcd")  // abcd again
+	if len(crossings) != 3 {
+		t.Fatalf("%+v", crossings)
+	}
+	want := []struct {
+		offset int64
+		rising bool
+	}{{4, true}, {6, false}, {15, true}}
+	for i, c := range crossings {
+		if c.Offset != want[i].offset || c.Rising != want[i].rising {
+			t.Errorf("crossing %d: %+v; want %+v", i, c, want[i])
+		}
+		if c.Rising != (c.Entropy > 1.5) {
+			t.Errorf("crossing %d: %+v", i, c)
+		}
+	}
+	w.Reset()
+	if w.Len() != 0 || w.Offset() != 0 || w.Entropy() != 0 {
+		t.Fatal("Reset did not empty window")
+	}
+	w.OnCross(1.5, nil)
+	w.WriteString("abcd")
+	if len(crossings) != 3 {
+		t.Fatal("nil callback was called")
+	}
+}
+
+func TestWindowZero(t *testing.T) {
+	var w bytemap.Window
+	if w.Len() != 0 || w.Entropy() != 0 {
+		t.Fatal(w.Len(), w.Entropy())
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Write to zero Window did not panic")
+		}
+	}()
+	w.WriteString("a")
+}